@@ -19,19 +19,25 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
 	"github.com/durudex/discord-promo-bot/internal/bot/command"
 	"github.com/durudex/discord-promo-bot/internal/bot/event"
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
 	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
 	"github.com/durudex/discord-promo-bot/internal/repository"
 	"github.com/durudex/discord-promo-bot/internal/service"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
 	"github.com/durudex/discord-promo-bot/pkg/database/mongodb"
 
+	"github.com/bwmarrin/discordgo"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -49,24 +55,46 @@ func init() {
 // A function that running the bot.
 func main() {
 	// Initialize config.
-	cfg, err := config.Init()
+	store, err := config.Init()
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to initialize config.")
 	}
 
-	// Creating a new discord bot.
-	b, err := bot.New(cfg.Bot.Token)
+	// Reloading config on config file changes.
+	store.Watch()
+
+	cfg := store.Load()
+
+	// Loading message catalogs.
+	catalog, err := locale.Load(cfg.Locale.Path, discordgo.Locale(cfg.Locale.Default))
 	if err != nil {
-		log.Fatal().Err(err).Msg("failed to create a discord session")
+		log.Fatal().Err(err).Msg("failed to load message catalogs")
 	}
 
-	// Running the discord bot.
-	if err := b.Run(); err != nil {
-		log.Fatal().Err(err).Msg("failed to running discord bot")
+	// Validating reply and log event templates.
+	if err := catalog.Validate(response.Templates); err != nil {
+		log.Fatal().Err(err).Msg("failed to validate message templates")
 	}
 
-	// Initializing the discord event handlers.
-	event.NewEvent(b).InitEvents()
+	res := response.New(store, catalog)
+
+	// Creating a new discord bot.
+	b, err := bot.New(&bot.BotConfig{
+		Token:          cfg.Bot.Token,
+		Guilds:         cfg.Bot.Guilds,
+		Global:         cfg.Bot.Global,
+		Timeout:        cfg.Bot.Timeout,
+		DeferThreshold: cfg.Bot.DeferAfter,
+		Localizer:      catalog,
+		Cooldown:       cooldownConfig(store, res),
+		PanicReporter: func(r *bot.Responder, err error) {
+			// Send a interaction respond error message.
+			res.Error(r, err)
+		},
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create a discord session")
+	}
 
 	// Creating a new mongodb client.
 	client, err := mongodb.NewClient(&mongodb.MongoConfig{
@@ -81,14 +109,41 @@ func main() {
 
 	// Creating a new repository.
 	repos := repository.NewRepository(client.Database(cfg.Database.Mongodb.Database))
+	// Loading promo epochs table.
+	epochs, err := loadEpochs(&cfg.Promo, repos.Epoch)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load promo epochs")
+	}
+
 	// Creating a new service.
-	service := service.NewService(repos)
+	service := service.NewService(repos, epochs)
 
 	// Starting promo monitoring.
 	startMonitor(service.Monitor, cfg.Promo.AutoSaveTTL)
 
-	// Registering all discord commands.
-	command.NewCommandPlugin(b, cfg, service).Register()
+	// Registering all discord commands before the bot starts handling
+	// interactions.
+	if err := command.NewCommandPlugin(b, store, service, catalog).Register(); err != nil {
+		log.Fatal().Err(err).Msg("failed to register command plugins")
+	}
+
+	// Running the discord bot.
+	if err := runBot(b, &cfg.Bot.HTTP); err != nil {
+		log.Fatal().Err(err).Msg("failed to running discord bot")
+	}
+
+	// Initializing the discord event handlers.
+	event.NewEvent(b).InitEvents()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Starting audit events delivery to the log channel.
+	go service.Audit.Run(ctx, audit.New(b.Session(), store, catalog).Deliver)
+
+	// Synchronizing discord commands.
+	if err := b.SyncCommands(); err != nil {
+		log.Fatal().Err(err).Msg("failed to synchronize discord commands")
+	}
 
 	// Quit in application.
 	quit := make(chan os.Signal, 1)
@@ -100,6 +155,10 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to close discord connection")
 	}
 
+	// Stopping audit events delivery, undelivered events are delivered on
+	// the next start.
+	cancel()
+
 	// Saving promo monitor.
 	if err := service.Monitor.Save(context.Background(), true); err != nil {
 		log.Error().Err(err).Msg("error saving monitor")
@@ -108,6 +167,66 @@ func main() {
 	log.Info().Msg("Discord Promo Bot stopping!")
 }
 
+// Creating a new discord application commands cooldowns config, members with
+// the review role are not limited.
+func cooldownConfig(store *config.Store, res *response.Response) *bot.CooldownConfig {
+	cfg := store.Load()
+	commands := make(map[string]bot.Cooldown, len(cfg.Bot.Cooldowns))
+
+	for path, cooldown := range cfg.Bot.Cooldowns {
+		commands[path] = bot.Cooldown{User: cooldown.User, Guild: cooldown.Guild, Command: cooldown.Command}
+	}
+
+	return &bot.CooldownConfig{
+		Commands: commands,
+		BypassRoles: func() []string {
+			return []string{store.Load().User.ReviewRole}
+		},
+		Reply: func(r *bot.Responder, retryAfter time.Duration) {
+			// Send a interaction respond error message.
+			res.Error(r, &domain.Error{
+				Code: domain.CodeRateLimited,
+				Key:  "errors.cooldown",
+				Data: response.CooldownData{Seconds: bot.CooldownSeconds(retryAfter)},
+			})
+		},
+	}
+}
+
+// Running the discord bot, if the interactions endpoint address is specified,
+// interactions are received over HTTP instead of the gateway.
+func runBot(b *bot.Bot, cfg *config.HTTPConfig) error {
+	// Checking is interactions endpoint mode.
+	if cfg.Addr == "" {
+		return b.Run()
+	}
+
+	// Decoding the application public key.
+	publicKey, err := hex.DecodeString(cfg.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	return b.RunHTTP(cfg.Addr, publicKey)
+}
+
+// Loading promo epochs table from the config or the database.
+func loadEpochs(cfg *config.PromoConfig, repos repository.Epoch) ([]domain.Monitor, error) {
+	// Checking is epochs taken from the config, they are validated with the
+	// config.
+	if cfg.EpochSource == config.EpochSourceConfig {
+		return cfg.EpochTable(), nil
+	}
+
+	// Getting promo epochs from the database.
+	epochs, err := repos.GetAll(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return epochs, domain.ValidateEpochs(epochs)
+}
+
 // Starting promo monitoring.
 func startMonitor(mon service.Monitor, ttl time.Duration) {
 	// Sync promo monitor with database.
@@ -4,13 +4,13 @@ go 1.18
 
 require (
 	github.com/bwmarrin/discordgo v0.26.1
+	github.com/fsnotify/fsnotify v1.5.4
 	github.com/rs/zerolog v1.27.0
 	github.com/spf13/viper v1.12.0
 	go.mongodb.org/mongo-driver v1.9.1
 )
 
 require (
-	github.com/fsnotify/fsnotify v1.5.4 // indirect
 	github.com/go-stack/stack v1.8.0 // indirect
 	github.com/golang/snappy v0.0.1 // indirect
 	github.com/gorilla/websocket v1.5.0 // indirect
@@ -0,0 +1,139 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Maximum length of the discord embed field value in characters.
+const maxFieldValueLength int = 1024
+
+// Discord audit log structure.
+type Audit struct {
+	// Discord bot session.
+	session bot.Session
+	// Config store.
+	cfg *config.Store
+	// Message catalog.
+	catalog *locale.Catalog
+}
+
+// Creating a new discord audit log.
+func New(session bot.Session, cfg *config.Store, catalog *locale.Catalog) *Audit {
+	return &Audit{session: session, cfg: cfg, catalog: catalog}
+}
+
+// Creating a new audit event performed by the interaction author.
+func Event(i *discordgo.InteractionCreate, eventType string) domain.AuditEvent {
+	author := bot.Author(i)
+
+	return domain.AuditEvent{
+		Type:        eventType,
+		Actor:       author.ID,
+		ActorName:   author.Username,
+		ActorAvatar: author.AvatarURL("128x128"),
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Delivering the audit event to the log channels of the event routes, the
+// channels to which the event is already delivered are skipped. It returns the
+// channels to which the event is delivered.
+func (a *Audit) Deliver(ctx context.Context, event domain.AuditEvent) ([]string, error) {
+	cfg := a.cfg.Load()
+	embed := a.embed(event, cfg.Bot.Color)
+	delivered := make([]string, 0, 1)
+
+	for _, channel := range Channels(cfg.Bot.LogRoutes, event, cfg.Bot.LogChannel) {
+		// Checking is audit event already delivered to the channel.
+		if contains(event.DeliveredTo, channel) {
+			continue
+		}
+
+		// Checking is context done.
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		// Send bot log message.
+		if _, err := a.session.ChannelMessageSendEmbed(channel, embed); err != nil {
+			return delivered, err
+		}
+
+		delivered = append(delivered, channel)
+	}
+
+	return delivered, nil
+}
+
+// Creating the audit event embed, the description is taken from the
+// "audit.<event type>" message template.
+func (a *Audit) embed(event domain.AuditEvent, color int) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Description: a.catalog.Default("audit."+event.Type, event),
+		Color:       color,
+		Timestamp:   event.CreatedAt.Format(time.RFC3339),
+	}
+
+	// Checking is event performed by a user, system events have no actor.
+	if event.Actor != "" {
+		embed.Author = &discordgo.MessageEmbedAuthor{
+			URL:     "https://discord.com/users/" + event.Actor,
+			Name:    event.ActorName,
+			IconURL: event.ActorAvatar,
+		}
+	}
+
+	// Checking is reason specified.
+	if event.Reason != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  a.catalog.Default("audit.reason", nil),
+			Value: reasonValue(event.Reason),
+		})
+	}
+
+	return embed
+}
+
+// Getting the reason embed field value, the reason is quoted and truncated to
+// the embed field value length.
+func reasonValue(reason string) string {
+	const (
+		quote     = "> "
+		ellipsis  = "..."
+		available = maxFieldValueLength - len(quote) - len(ellipsis)
+	)
+
+	text := []rune(reason)
+
+	// Checking is reason too long.
+	if len(text) > available+len(ellipsis) {
+		text = append(text[:available], []rune(ellipsis)...)
+	}
+
+	return quote + string(text)
+}
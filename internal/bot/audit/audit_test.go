@@ -0,0 +1,197 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Fake discord session, sending messages to the failing channel fails.
+type session struct {
+	*bottest.Session
+	failing string
+}
+
+// Recording the discord channel embed message.
+func (s *session) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
+	if channelID == s.failing {
+		return nil, errors.New("discord is unavailable")
+	}
+
+	return s.Session.ChannelMessageSendEmbed(channelID, embed)
+}
+
+// Test delivering the audit event to the log channels.
+func TestAudit_Deliver(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	cfg := config.NewStore(&config.Config{Bot: config.BotConfig{
+		LogRoutes: []config.LogRouteConfig{
+			{Events: []string{domain.AuditPromoUsed}, Channels: []string{"promo", "moderators"}},
+		},
+	}})
+
+	// Tests structures.
+	tests := []struct {
+		name         string
+		deliveredTo  []string
+		failing      string
+		canceled     bool
+		want         []string
+		wantErr      bool
+		wantMessages map[string]int
+	}{
+		{
+			name:         "OK",
+			want:         []string{"promo", "moderators"},
+			wantMessages: map[string]int{"promo": 1, "moderators": 1},
+		},
+		{
+			name:         "Partial",
+			failing:      "moderators",
+			want:         []string{"promo"},
+			wantErr:      true,
+			wantMessages: map[string]int{"promo": 1},
+		},
+		{
+			name:         "Already Delivered",
+			deliveredTo:  []string{"promo"},
+			want:         []string{"moderators"},
+			wantMessages: map[string]int{"moderators": 1},
+		},
+		{
+			name:         "Canceled",
+			canceled:     true,
+			want:         []string{},
+			wantErr:      true,
+			wantMessages: map[string]int{},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &session{Session: bottest.NewSession(), failing: tt.failing}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Checking is context must be canceled.
+			if tt.canceled {
+				cancel()
+			}
+
+			got, err := audit.New(s, cfg, catalog).Deliver(ctx, domain.AuditEvent{
+				Type:        domain.AuditPromoUsed,
+				Promo:       "durudex",
+				DeliveredTo: tt.deliveredTo,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error delivering audit event: %v", err)
+			}
+
+			// Check for similarity of a delivered channels.
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error delivered channels are not similar: %v", got)
+			}
+
+			for _, channel := range []string{"promo", "moderators"} {
+				if messages := len(s.ChannelMessages(channel)); messages != tt.wantMessages[channel] {
+					t.Errorf("error %s channel messages are not similar: %d", channel, messages)
+				}
+			}
+		})
+	}
+}
+
+// Test truncating the audit event reason to the embed field value length.
+func TestAudit_DeliverReason(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	cfg := config.NewStore(&config.Config{Bot: config.BotConfig{
+		LogRoutes: []config.LogRouteConfig{
+			{Events: []string{domain.AuditBalanceAdjusted}, Channels: []string{"balance"}},
+		},
+	}})
+
+	// Tests structures.
+	tests := []struct {
+		name   string
+		reason string
+		want   string
+	}{
+		{
+			name:   "OK",
+			reason: "Event winner",
+			want:   "> Event winner",
+		},
+		{
+			name:   "Long",
+			reason: strings.Repeat("ї", 2000),
+			want:   "> " + strings.Repeat("ї", 1019) + "...",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewSession()
+
+			if _, err := audit.New(s, cfg, catalog).Deliver(context.Background(), domain.AuditEvent{
+				Type:   domain.AuditBalanceAdjusted,
+				Reason: tt.reason,
+			}); err != nil {
+				t.Fatalf("error delivering audit event: %s", err.Error())
+			}
+
+			messages := s.ChannelMessages("balance")
+			if len(messages) != 1 || len(messages[0].Fields) != 1 {
+				t.Fatalf("error unexpected messages: %v", messages)
+			}
+
+			value := messages[0].Fields[0].Value
+
+			if value != tt.want {
+				t.Errorf("error reason are not similar: %s", value)
+			}
+			if utf8.RuneCountInString(value) > 1024 {
+				t.Errorf("error reason is too long: %d", utf8.RuneCountInString(value))
+			}
+		})
+	}
+}
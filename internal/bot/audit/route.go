@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit
+
+import (
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+)
+
+// Getting the log channels of the audit event. The event is sent to the
+// channels of all routes it matches, events of types that are not routed are
+// sent to the fallback channel.
+func Channels(routes []config.LogRouteConfig, event domain.AuditEvent, fallback string) []string {
+	var (
+		channels []string
+		routed   bool
+	)
+
+	for _, route := range routes {
+		if !contains(route.Events, event.Type) {
+			continue
+		}
+
+		routed = true
+
+		// Checking is event amount less than the route min amount.
+		if abs(event.Amount) < route.MinAmount {
+			continue
+		}
+
+		for _, channel := range route.Channels {
+			if !contains(channels, channel) {
+				channels = append(channels, channel)
+			}
+		}
+	}
+
+	// Checking is event type routed.
+	if !routed && fallback != "" {
+		return []string{fallback}
+	}
+
+	return channels
+}
+
+// Check is target value in the list of values.
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Getting the absolute value.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package audit_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+)
+
+// Test getting the log channels of the audit event.
+func TestChannels(t *testing.T) {
+	routes := []config.LogRouteConfig{
+		{Events: []string{domain.AuditPromoCreated, domain.AuditPromoUsed}, Channels: []string{"promo"}},
+		{Events: []string{domain.AuditBalanceAdjusted}, Channels: []string{"balance"}},
+		{Events: []string{domain.AuditBalanceAdjusted}, Channels: []string{"moderators", "balance"}, MinAmount: 1000},
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name  string
+		event domain.AuditEvent
+		want  []string
+	}{
+		{
+			name:  "OK",
+			event: domain.AuditEvent{Type: domain.AuditPromoUsed, Amount: 1000},
+			want:  []string{"promo"},
+		},
+		{
+			name:  "Min Amount",
+			event: domain.AuditEvent{Type: domain.AuditBalanceAdjusted, Amount: -1500},
+			want:  []string{"balance", "moderators"},
+		},
+		{
+			name:  "Less Than Min Amount",
+			event: domain.AuditEvent{Type: domain.AuditBalanceAdjusted, Amount: 50},
+			want:  []string{"balance"},
+		},
+		{
+			name:  "Fallback",
+			event: domain.AuditEvent{Type: domain.AuditEpochAdvanced},
+			want:  []string{"log"},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Getting the log channels.
+			got := audit.Channels(routes, tt.event, "log")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error channels are not similar: %v", got)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@
 package basic
 
 import (
+	"context"
+
 	"github.com/durudex/discord-promo-bot/pkg/bot"
 
 	"github.com/bwmarrin/discordgo"
@@ -25,13 +27,10 @@ import (
 )
 
 // GitHub bot command.
-func (p *BasicPlugin) GitHubCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *BasicPlugin) githubCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.githubCommandApplication(),
 		Handler:            p.githubCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
 	}
 }
 
@@ -44,7 +43,7 @@ func (p *BasicPlugin) githubCommandApplication() discordgo.ApplicationCommand {
 }
 
 // GitHub command handler.
-func (p *BasicPlugin) githubCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (p *BasicPlugin) githubCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
 	// Send a interaction respond message.
 	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -17,7 +17,11 @@
 
 package basic
 
-import "github.com/durudex/discord-promo-bot/pkg/bot"
+import (
+	"context"
+
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+)
 
 // Basic command plugin structure.
 type BasicPlugin struct{ bot *bot.Bot }
@@ -27,8 +31,25 @@ func NewBasicPlugin(bot *bot.Bot) *BasicPlugin {
 	return &BasicPlugin{bot: bot}
 }
 
-// Registering all basic plugin commands.
-func (p *BasicPlugin) RegisterCommands() {
-	// Register basic plugin GitHub bot command.
-	p.GitHubCommand()
+// Getting the basic plugin name.
+func (p *BasicPlugin) Name() string { return "basic" }
+
+// Getting all basic plugin commands.
+func (p *BasicPlugin) Commands() []*bot.Command {
+	return []*bot.Command{p.githubCommand()}
 }
+
+// Getting all basic plugin message components.
+func (p *BasicPlugin) Components() []*bot.Component { return nil }
+
+// Getting all basic plugin modals.
+func (p *BasicPlugin) Modals() []*bot.Modal { return nil }
+
+// Getting all basic plugin event handlers.
+func (p *BasicPlugin) Handlers() []any { return nil }
+
+// Starting the basic plugin.
+func (p *BasicPlugin) Start(ctx context.Context) error { return nil }
+
+// Stopping the basic plugin.
+func (p *BasicPlugin) Stop(ctx context.Context) error { return nil }
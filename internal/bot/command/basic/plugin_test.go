@@ -0,0 +1,62 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package basic_test
+
+import (
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/command/basic"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+)
+
+// Test handling basic plugin interactions.
+func TestBasicPlugin(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name        string
+		command     string
+		wantContent string
+	}{
+		{name: "GitHub", command: "github", wantContent: "https://github.com/durudex/discord-promo-bot"},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bot.New(&bot.BotConfig{Token: "123"})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			// Registering the basic plugin.
+			if err := b.RegisterPlugin(basic.NewBasicPlugin(b)); err != nil {
+				t.Fatalf("error registering plugin: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction.
+			b.Handle(s, bottest.Command(tt.command))
+
+			if content := s.Message().Content; content != tt.wantContent {
+				t.Errorf("error content are not similar: %s", content)
+			}
+		})
+	}
+}
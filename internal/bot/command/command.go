@@ -18,10 +18,14 @@
 package command
 
 import (
+	"fmt"
+
 	"github.com/durudex/discord-promo-bot/internal/bot/command/basic"
 	"github.com/durudex/discord-promo-bot/internal/bot/command/monitor"
 	"github.com/durudex/discord-promo-bot/internal/bot/command/user"
+	"github.com/durudex/discord-promo-bot/internal/bot/middleware"
 	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/locale"
 	"github.com/durudex/discord-promo-bot/internal/service"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
 )
@@ -30,23 +34,59 @@ import (
 type CommandPlugin struct {
 	// Bot structure.
 	bot *bot.Bot
-	// Config variables.
-	cfg *config.Config
+	// Config store.
+	cfg *config.Store
 	// Service structure.
 	service *service.Service
+	// Message catalog.
+	catalog *locale.Catalog
 }
 
 // Creating a new command plugin.
-func NewCommandPlugin(bot *bot.Bot, cfg *config.Config, service *service.Service) *CommandPlugin {
-	return &CommandPlugin{bot: bot, cfg: cfg, service: service}
+func NewCommandPlugin(
+	bot *bot.Bot,
+	cfg *config.Store,
+	service *service.Service,
+	catalog *locale.Catalog,
+) *CommandPlugin {
+	return &CommandPlugin{bot: bot, cfg: cfg, service: service, catalog: catalog}
+}
+
+// Registering command plugins enabled in the config.
+func (p *CommandPlugin) Register() error {
+	// Registering global middlewares.
+	p.bot.Use(middleware.Logger())
+
+	plugins := p.plugins()
+
+	for _, cfg := range p.cfg.Load().Plugins {
+		plugin, ok := plugins[cfg.Name]
+		if !ok {
+			return fmt.Errorf("unknown plugin %s", cfg.Name)
+		}
+
+		// Registering the plugin.
+		if err := p.bot.RegisterPlugin(plugin, cfg.Disabled...); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// Registering command plugins.
-func (p *CommandPlugin) Register() {
-	// Registering all basic commands.
-	basic.NewBasicPlugin(p.bot).RegisterCommands()
-	// Registering all user commands.
-	user.NewUserPlugin(p.bot, p.cfg, p.service.User).RegisterCommands()
-	// Registering all monitor plugin commands.
-	monitor.NewMonitorPlugin(p.bot, &p.cfg.Bot, p.service.Monitor).RegisterCommands()
+// Getting all command plugins by name.
+func (p *CommandPlugin) plugins() map[string]bot.Plugin {
+	plugins := []bot.Plugin{
+		basic.NewBasicPlugin(p.bot),
+		user.NewUserPlugin(p.bot, p.cfg, p.service.User, p.service.Audit, p.catalog),
+		monitor.NewMonitorPlugin(p.bot, p.cfg, p.service.Monitor, p.service.Audit, p.catalog),
+	}
+
+	byName := make(map[string]bot.Plugin, len(plugins))
+
+	for _, plugin := range plugins {
+		byName[plugin.Name()] = plugin
+	}
+
+	return byName
 }
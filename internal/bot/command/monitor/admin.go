@@ -0,0 +1,189 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package monitor
+
+import (
+	"context"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
+	"github.com/durudex/discord-promo-bot/internal/bot/middleware"
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+var EpochAdminCommandMemberPermission int64 = discordgo.PermissionManageServer
+
+// Epoch admin set limit command options.
+type setLimitOptions struct {
+	Limit int `option:"limit,required,min=0" description:"Remaining usage limit of the current epoch."`
+}
+
+// Epoch admin set reward command options.
+type setRewardOptions struct {
+	Reward int `option:"reward,required,min=1" description:"Reward of the current epoch."`
+}
+
+// Epoch admin bot command.
+func (p *MonitorPlugin) epochAdminCommand() *bot.Command {
+	return &bot.Command{
+		ApplicationCommand: p.epochAdminCommandApplication(),
+		SubCommands: map[string]bot.HandlerFunc{
+			"advance":    p.epochAdvanceHandler,
+			"set-limit":  p.epochSetLimitHandler,
+			"set-reward": p.epochSetRewardHandler,
+			"reset":      p.epochResetHandler,
+		},
+		Middlewares: []bot.Middleware{middleware.GuildOnly(p.catalog, p.response), middleware.ReviewRole(p.cfg, p.catalog, p.response)},
+	}
+}
+
+// Epoch admin command application.
+func (p *MonitorPlugin) epochAdminCommandApplication() discordgo.ApplicationCommand {
+	return discordgo.ApplicationCommand{
+		Name:                     "epoch-admin",
+		Description:              "The command managing the monitor epoch.",
+		DefaultMemberPermissions: &EpochAdminCommandMemberPermission,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "advance",
+				Description: "Start the next epoch.",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set-limit",
+				Description: "Set the remaining usage limit of the current epoch.",
+				Options:     bot.MustOptions(setLimitOptions{}),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "set-reward",
+				Description: "Set the reward of the current epoch.",
+				Options:     bot.MustOptions(setRewardOptions{}),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reset",
+				Description: "Reset the reward and usage limit of the current epoch.",
+			},
+		},
+	}
+}
+
+// Epoch admin advance command handler.
+func (p *MonitorPlugin) epochAdvanceHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	// Advancing the monitor to the next epoch.
+	monitor, err := p.service.Advance(ctx)
+
+	p.epochAdminRespond(ctx, i, r, "epoch-admin.advance", domain.AuditEpochAdvanced, monitor, err)
+}
+
+// Epoch admin set limit command handler.
+func (p *MonitorPlugin) epochSetLimitHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	var options setLimitOptions
+
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
+	}
+
+	// Setting the current epoch usage limit.
+	monitor, err := p.service.SetLimit(ctx, options.Limit)
+
+	p.epochAdminRespond(ctx, i, r, "epoch-admin.set-limit", domain.AuditEpochUpdated, monitor, err)
+}
+
+// Epoch admin set reward command handler.
+func (p *MonitorPlugin) epochSetRewardHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	var options setRewardOptions
+
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
+	}
+
+	// Setting the current epoch reward.
+	monitor, err := p.service.SetReward(ctx, options.Reward)
+
+	p.epochAdminRespond(ctx, i, r, "epoch-admin.set-reward", domain.AuditEpochUpdated, monitor, err)
+}
+
+// Epoch admin reset command handler.
+func (p *MonitorPlugin) epochResetHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	// Resetting the current epoch.
+	monitor, err := p.service.Reset(ctx)
+
+	p.epochAdminRespond(ctx, i, r, "epoch-admin.reset", domain.AuditEpochUpdated, monitor, err)
+}
+
+// Responding with the epoch admin action result and recording the audit event.
+func (p *MonitorPlugin) epochAdminRespond(
+	ctx context.Context,
+	i *discordgo.InteractionCreate,
+	r *bot.Responder,
+	key string,
+	eventType string,
+	monitor domain.Monitor,
+	err error,
+) {
+	if err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
+	}
+
+	// Send a interaction respond message.
+	if err := p.response.Respond(r, response.Admin, &discordgo.InteractionResponseData{
+		Content: p.catalog.Message(i.Locale, key, monitor),
+	}); err != nil {
+		log.Warn().Err(err).Msg("failed to send interaction respond message")
+	}
+
+	event := audit.Event(i, eventType)
+	event.Epoch = monitor.Id
+	event.Amount = monitor.Reward
+	event.UsageLimit = monitor.UsageLimit
+
+	// Recording the audit event.
+	if err := p.audit.Record(event); err != nil {
+		log.Error().Err(err).Msg("failed to record audit event")
+	}
+}
@@ -20,6 +20,8 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/durudex/discord-promo-bot/internal/bot/response"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
@@ -28,14 +30,17 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// Epoch command options.
+type epochOptions struct {
+	Epoch int `option:"epoch" description:"Get information about the specified epoch."`
+}
+
 // Epoch bot command.
-func (p *MonitorPlugin) EpochCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *MonitorPlugin) epochCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.epochCommandApplication(),
 		Handler:            p.epochCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
+		Autocomplete:       map[string]bot.AutocompleteHandler{"epoch": p.epochAutocompleteHandler},
 	}
 }
 
@@ -44,58 +49,77 @@ func (p *MonitorPlugin) epochCommandApplication() discordgo.ApplicationCommand {
 	return discordgo.ApplicationCommand{
 		Name:        "epoch",
 		Description: "The command outputs all public information about the monitor epoch",
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionInteger,
-				Name:        "epoch",
-				Description: "Get information about the specified epoch.",
-				Required:    false,
-			},
-		},
+		Options:     bot.MustOptions(epochOptions{}),
 	}
 }
 
 // Epoch command handler.
-func (p *MonitorPlugin) epochCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var (
-		id      int
-		current bool
-	)
-
-	// Setting the monitor query options.
-	if i.ApplicationCommandData().Options != nil {
-		id = int(i.ApplicationCommandData().Options[0].IntValue())
-	} else {
-		current = true
+func (p *MonitorPlugin) epochCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	var options epochOptions
+
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
 	}
 
-	// Getting a promo monitor.
-	monitor, err := p.service.Get(context.Background(), id, current, false)
+	// Getting a promo monitor, if the epoch is not specified, the current
+	// epoch is used.
+	monitor, err := p.service.Get(ctx, options.Epoch, options.Epoch == 0, false)
 	if err != nil {
 		// Send a interaction respond error message.
-		if err := response.InteractionError(s, i, err); err != nil {
-			log.Warn().Err(err).Msg("failed to getting promo monitor")
-		}
+		p.response.Error(r, err)
 
 		return
 	}
 
 	// Send a interaction respond message.
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title: fmt.Sprintf("Epoch %d", monitor.Id),
-					Description: fmt.Sprintf("**Reward:** %d\n", monitor.Reward) +
-						fmt.Sprintf("**Usage Limit:** %d\n", monitor.UsageLimit) +
-						fmt.Sprintf("**Started In:** <t:%d:R>\n", monitor.StartedIn.Unix()) +
-						fmt.Sprintf("**Updated At:** <t:%d:R>\n", monitor.UpdatedAt.Unix()),
-					Color: p.botCfg.Color,
-				},
+	if err := p.response.Respond(r, response.Info, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       p.catalog.Message(i.Locale, "epoch.title", monitor),
+				Description: p.catalog.Message(i.Locale, "epoch.info", monitor),
+				Color:       p.cfg.Load().Bot.Color,
 			},
 		},
 	}); err != nil {
 		log.Warn().Err(err).Msg("failed to send interaction respond message")
 	}
 }
+
+// Epoch command epoch option autocomplete handler.
+func (p *MonitorPlugin) epochAutocompleteHandler(
+	ctx context.Context,
+	s bot.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) []*discordgo.ApplicationCommandOptionChoice {
+	var value string
+
+	// Getting the typed value, it can be a partial number.
+	if option.Value != nil {
+		value = fmt.Sprint(option.Value)
+	}
+
+	epochs := p.service.Epochs()
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(epochs))
+
+	for _, epoch := range epochs {
+		// Checking is the epoch id starts with the typed value.
+		if !strings.HasPrefix(strconv.Itoa(epoch.Id), value) {
+			continue
+		}
+
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  p.catalog.Message(i.Locale, "epoch.choice", epoch),
+			Value: epoch.Id,
+		})
+	}
+
+	return choices
+}
@@ -18,7 +18,11 @@
 package monitor
 
 import (
+	"context"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
 	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/locale"
 	"github.com/durudex/discord-promo-bot/internal/service"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
 )
@@ -27,19 +31,55 @@ import (
 type MonitorPlugin struct {
 	// Bot structure.
 	bot *bot.Bot
-	// Bot config variables.
-	botCfg *config.BotConfig
+	// Config store.
+	cfg *config.Store
 	// Monitor service.
 	service service.Monitor
+	// Audit service.
+	audit service.Audit
+	// Message catalog.
+	catalog *locale.Catalog
+	// Bot response.
+	response *response.Response
 }
 
 // Creating a new monitor service.
-func NewMonitorPlugin(bot *bot.Bot, cfg *config.BotConfig, service service.Monitor) *MonitorPlugin {
-	return &MonitorPlugin{bot: bot, botCfg: cfg, service: service}
+func NewMonitorPlugin(
+	bot *bot.Bot,
+	cfg *config.Store,
+	service service.Monitor,
+	audit service.Audit,
+	catalog *locale.Catalog,
+) *MonitorPlugin {
+	return &MonitorPlugin{
+		bot:      bot,
+		cfg:      cfg,
+		service:  service,
+		audit:    audit,
+		catalog:  catalog,
+		response: response.New(cfg, catalog),
+	}
 }
 
-// Registering all monitor plugin commands.
-func (p *MonitorPlugin) RegisterCommands() {
-	// Register monitor plugin epoch bot command.
-	p.EpochCommand()
+// Getting the monitor plugin name.
+func (p *MonitorPlugin) Name() string { return "monitor" }
+
+// Getting all monitor plugin commands.
+func (p *MonitorPlugin) Commands() []*bot.Command {
+	return []*bot.Command{p.epochCommand(), p.epochAdminCommand()}
 }
+
+// Getting all monitor plugin message components.
+func (p *MonitorPlugin) Components() []*bot.Component { return nil }
+
+// Getting all monitor plugin modals.
+func (p *MonitorPlugin) Modals() []*bot.Modal { return nil }
+
+// Getting all monitor plugin event handlers.
+func (p *MonitorPlugin) Handlers() []any { return nil }
+
+// Starting the monitor plugin.
+func (p *MonitorPlugin) Start(ctx context.Context) error { return nil }
+
+// Stopping the monitor plugin.
+func (p *MonitorPlugin) Stop(ctx context.Context) error { return nil }
@@ -0,0 +1,307 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package monitor_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/command/monitor"
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/internal/service"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Testing promo epochs table.
+var testEpochs = []domain.Monitor{
+	{Id: 1, Reward: 1000, UsageLimit: 500},
+	{Id: 2, Reward: 900, UsageLimit: 2000},
+	{Id: 3, Reward: 800, UsageLimit: 2500},
+	{Id: 4, Reward: 700, UsageLimit: 10000},
+	{Id: 5, Reward: 600, UsageLimit: 10000},
+}
+
+// In-memory monitor service.
+type monitorService struct{ current int }
+
+// Getting a promo monitor.
+func (s *monitorService) Get(ctx context.Context, id int, current, last bool) (domain.Monitor, error) {
+	if current {
+		id = s.current
+	}
+
+	if id < 1 || id > len(testEpochs) {
+		return domain.Monitor{}, &domain.Error{Code: domain.CodeNotFound, Key: "errors.epoch-not-found"}
+	}
+
+	monitor := testEpochs[id-1]
+	monitor.StartedIn = time.Unix(1660000000, 0)
+	monitor.UpdatedAt = time.Unix(1660000000, 0)
+
+	return monitor, nil
+}
+
+// Saving promo monitor.
+func (s *monitorService) Save(ctx context.Context, skip bool, monitor ...domain.Monitor) error {
+	return nil
+}
+
+// Sync promo monitor with database.
+func (s *monitorService) Sync(ctx context.Context) error { return nil }
+
+// Using a promo code with monitor.
+func (s *monitorService) Use() (service.MonitorUsage, error) { return service.MonitorUsage{}, nil }
+
+// De using promo code with monitor.
+func (s *monitorService) DeUse(usage service.MonitorUsage) {}
+
+// Getting promo epochs table.
+func (s *monitorService) Epochs() []domain.Monitor { return testEpochs }
+
+// Advancing the promo monitor to the next epoch.
+func (s *monitorService) Advance(ctx context.Context) (domain.Monitor, error) {
+	if s.current == len(testEpochs) {
+		return domain.Monitor{}, &domain.Error{Code: domain.CodeFailedPrecondition, Key: "errors.last-epoch"}
+	}
+
+	s.current++
+
+	return s.Get(ctx, 0, true, false)
+}
+
+// Setting the current epoch usage limit.
+func (s *monitorService) SetLimit(ctx context.Context, limit int) (domain.Monitor, error) {
+	if limit < 0 {
+		return domain.Monitor{}, &domain.Error{Code: domain.CodeInvalidArgument, Key: "errors.negative-limit"}
+	}
+
+	monitor, err := s.Get(ctx, 0, true, false)
+	monitor.UsageLimit = limit
+
+	return monitor, err
+}
+
+// Setting the current epoch reward.
+func (s *monitorService) SetReward(ctx context.Context, reward int) (domain.Monitor, error) {
+	if reward <= 0 {
+		return domain.Monitor{}, &domain.Error{Code: domain.CodeInvalidArgument, Key: "errors.invalid-reward"}
+	}
+
+	monitor, err := s.Get(ctx, 0, true, false)
+	monitor.Reward = reward
+
+	return monitor, err
+}
+
+// Resetting the current epoch.
+func (s *monitorService) Reset(ctx context.Context) (domain.Monitor, error) {
+	return s.Get(ctx, 0, true, false)
+}
+
+// In-memory audit service.
+type auditService struct{ events []domain.AuditEvent }
+
+// Recording an audit event.
+func (s *auditService) Record(event domain.AuditEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+// Delivering recorded audit events until the context is canceled.
+func (s *auditService) Run(ctx context.Context, deliver service.AuditDeliverer) {}
+
+// Creating a new epoch admin interaction.
+func epochAdmin(review bool, name string, options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+	i := bottest.Command("epoch-admin", &discordgo.ApplicationCommandInteractionDataOption{
+		Name:    name,
+		Type:    discordgo.ApplicationCommandOptionSubCommand,
+		Options: options,
+	})
+
+	// Checking is interaction created by the reviewer.
+	if review {
+		i.Member.Roles = []string{"review"}
+	}
+
+	return i
+}
+
+// Test handling monitor plugin interactions.
+func TestMonitorPlugin(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		interaction *discordgo.InteractionCreate
+		wantType    discordgo.InteractionResponseType
+		wantTitle   string
+		wantEmbed   string
+		wantContent string
+		wantChoices []any
+		wantEvents  int
+	}{
+		{
+			name:        "Current Epoch",
+			interaction: bottest.Command("epoch"),
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantTitle:   "Epoch 2",
+		},
+		{
+			name: "Epoch",
+			interaction: bottest.Command(
+				"epoch",
+				bottest.Option("epoch", discordgo.ApplicationCommandOptionInteger, float64(4)),
+			),
+			wantType:  discordgo.InteractionResponseChannelMessageWithSource,
+			wantTitle: "Epoch 4",
+		},
+		{
+			name: "Epoch Not Found",
+			interaction: bottest.Command(
+				"epoch",
+				bottest.Option("epoch", discordgo.ApplicationCommandOptionInteger, float64(9)),
+			),
+			wantType:  discordgo.InteractionResponseChannelMessageWithSource,
+			wantEmbed: "Epoch not found.",
+		},
+		{
+			name: "Autocomplete",
+			interaction: bottest.Autocomplete(
+				"epoch",
+				bottest.Option("epoch", discordgo.ApplicationCommandOptionInteger, ""),
+			),
+			wantType:    discordgo.InteractionApplicationCommandAutocompleteResult,
+			wantChoices: []any{1, 2, 3, 4, 5},
+		},
+		{
+			name: "Autocomplete Prefix",
+			interaction: bottest.Autocomplete(
+				"epoch",
+				bottest.Option("epoch", discordgo.ApplicationCommandOptionInteger, "3"),
+			),
+			wantType:    discordgo.InteractionApplicationCommandAutocompleteResult,
+			wantChoices: []any{3},
+		},
+		{
+			name:        "Advance",
+			interaction: epochAdmin(true, "advance"),
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantContent: "Epoch 3 has started, the reward is `800` and the usage limit is `2500`.",
+			wantEvents:  1,
+		},
+		{
+			name: "Set Limit",
+			interaction: epochAdmin(true, "set-limit",
+				bottest.Option("limit", discordgo.ApplicationCommandOptionInteger, float64(100)),
+			),
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantContent: "The usage limit of epoch 2 is set to `100`.",
+			wantEvents:  1,
+		},
+		{
+			name: "Set Reward Below Min",
+			interaction: epochAdmin(true, "set-reward",
+				bottest.Option("reward", discordgo.ApplicationCommandOptionInteger, float64(0)),
+			),
+			wantType:  discordgo.InteractionResponseChannelMessageWithSource,
+			wantTitle: "Internal bot error",
+		},
+		{
+			name:        "Reset Without Role",
+			interaction: epochAdmin(false, "reset"),
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantEmbed:   "You do not have access to this command!",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bot.New(&bot.BotConfig{Token: "123"})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			audit := &auditService{}
+
+			// Registering the monitor plugin.
+			if err := b.RegisterPlugin(monitor.NewMonitorPlugin(
+				b,
+				config.NewStore(&config.Config{
+					Bot:  config.BotConfig{Color: 0xa735ed},
+					User: config.UserConfig{ReviewRole: "review"},
+				}),
+				&monitorService{current: 2},
+				audit,
+				catalog,
+			)); err != nil {
+				t.Fatalf("error registering plugin: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction.
+			b.Handle(s, tt.interaction)
+
+			responses := s.Responses()
+			if len(responses) != 1 || responses[0].Type != tt.wantType {
+				t.Fatalf("error unexpected responses: %v", responses)
+			}
+
+			message := s.Message()
+
+			if tt.wantEmbed != "" && (len(message.Embeds) != 1 || message.Embeds[0].Description != tt.wantEmbed) {
+				t.Errorf("error embed description are not similar: %v", message.Embeds)
+			}
+			if tt.wantTitle != "" && (len(message.Embeds) != 1 || message.Embeds[0].Title != tt.wantTitle) {
+				t.Errorf("error embed title are not similar: %v", message.Embeds)
+			}
+			if tt.wantContent != "" && message.Content != tt.wantContent {
+				t.Errorf("error content are not similar: %s", message.Content)
+			}
+			if len(audit.events) != tt.wantEvents {
+				t.Errorf("error unexpected audit events: %v", audit.events)
+			}
+
+			if tt.wantChoices != nil {
+				choices := make([]any, 0, len(message.Choices))
+
+				for _, choice := range message.Choices {
+					choices = append(choices, choice.Value)
+				}
+
+				// Check for similarity of a choices.
+				if !reflect.DeepEqual(choices, tt.wantChoices) {
+					t.Errorf("error choices are not similar: %v", choices)
+				}
+			}
+		})
+	}
+}
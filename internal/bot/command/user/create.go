@@ -19,8 +19,8 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
 	"github.com/durudex/discord-promo-bot/internal/bot/response"
 	"github.com/durudex/discord-promo-bot/internal/domain"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
@@ -29,14 +29,16 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// Create command options.
+type createOptions struct {
+	Promo string `option:"promo,required" description:"Unique promo code."`
+}
+
 // Create bot command.
-func (p *UserPlugin) CreateCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *UserPlugin) createCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.createCommandApplication(),
 		Handler:            p.createCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
 	}
 }
 
@@ -45,70 +47,47 @@ func (p *UserPlugin) createCommandApplication() discordgo.ApplicationCommand {
 	return discordgo.ApplicationCommand{
 		Name:        "create",
 		Description: "The command creating a new user promo code.",
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "promo",
-				Description: "Unique promo code.",
-				Required:    true,
-			},
-		},
+		Options:     bot.MustOptions(createOptions{}),
 	}
 }
 
 // Create command handler.
-func (p *UserPlugin) createCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var author *discordgo.User
-
-	// Checking where the command was use.
-	if i.Interaction.User == nil {
-		author = i.Interaction.Member.User
-	} else {
-		author = i.Interaction.User
+func (p *UserPlugin) createCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	var options createOptions
+
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
 	}
 
+	author := bot.Author(i)
+
 	// Updating a user.
-	if err := p.service.Update(
-		context.Background(),
-		domain.User{
-			Id:    author.ID,
-			Promo: i.ApplicationCommandData().Options[0].StringValue(),
-		},
-	); err != nil {
+	if err := p.service.Update(ctx, domain.User{Id: author.ID, Promo: options.Promo}); err != nil {
 		// Send a interaction respond error message.
-		if err := response.InteractionError(s, i, err); err != nil {
-			log.Warn().Err(err).Msg("failed to send interaction respond error message")
-		}
+		p.response.Error(r, err)
 
 		return
 	}
 
 	// Send a interaction respond message.
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("You created promo code `%s`", i.ApplicationCommandData().Options[0].StringValue()),
-		},
+	if err := p.response.Respond(r, response.Success, &discordgo.InteractionResponseData{
+		Content: p.catalog.Message(i.Locale, "create.success", response.PromoData{Promo: options.Promo}),
 	}); err != nil {
 		log.Warn().Err(err).Msg("failed to send interaction respond message")
 	}
 
-	// Send bot log message.
-	if _, err := s.ChannelMessageSendEmbed(
-		p.botCfg.LogChannel,
-		&discordgo.MessageEmbed{
-			Author: &discordgo.MessageEmbedAuthor{
-				URL:     "https://discord.com/users/" + author.ID,
-				Name:    author.Username,
-				IconURL: author.AvatarURL("128x128"),
-			},
-			Description: fmt.Sprintf(
-				"User created a new promo code `%s`.",
-				i.ApplicationCommandData().Options[0].StringValue(),
-			),
-			Color: p.botCfg.Color,
-		},
-	); err != nil {
-		log.Warn().Err(err).Msg("failed to send channel message")
+	event := audit.Event(i, domain.AuditPromoCreated)
+	event.Promo = options.Promo
+
+	// Recording the audit event.
+	if err := p.audit.Record(event); err != nil {
+		log.Error().Err(err).Msg("failed to record audit event")
 	}
 }
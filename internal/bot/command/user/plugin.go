@@ -18,38 +18,85 @@
 package user
 
 import (
+	"context"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
 	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/locale"
 	"github.com/durudex/discord-promo-bot/internal/service"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
 )
 
 // User command plugin structure.
 type UserPlugin struct {
 	// Bot structure.
 	bot *bot.Bot
-	// User config variables.
-	userCfg *config.UserConfig
-	// Bot config variables.
-	botCfg *config.BotConfig
+	// Config store.
+	cfg *config.Store
 	// User service.
 	service service.User
+	// Audit service.
+	audit service.Audit
+	// Message catalog.
+	catalog *locale.Catalog
+	// Bot response.
+	response *response.Response
 }
 
 // Creating a new user command plugin.
-func NewUserPlugin(bot *bot.Bot, cfg *config.Config, service service.User) *UserPlugin {
-	return &UserPlugin{bot: bot, userCfg: &cfg.User, botCfg: &cfg.Bot, service: service}
+func NewUserPlugin(
+	bot *bot.Bot,
+	cfg *config.Store,
+	service service.User,
+	audit service.Audit,
+	catalog *locale.Catalog,
+) *UserPlugin {
+	return &UserPlugin{
+		bot:      bot,
+		cfg:      cfg,
+		service:  service,
+		audit:    audit,
+		catalog:  catalog,
+		response: response.New(cfg, catalog),
+	}
+}
+
+// Getting the user plugin name.
+func (p *UserPlugin) Name() string { return "user" }
+
+// Getting all user plugin commands.
+func (p *UserPlugin) Commands() []*bot.Command {
+	return []*bot.Command{
+		p.registerCommand(),
+		p.userCommand(),
+		p.createCommand(),
+		p.useCommand(),
+		p.updateBalanceCommand(),
+		p.profileCommand(),
+		p.reviewCommand(),
+	}
 }
 
-// Registering all user plugin commands.
-func (p *UserPlugin) RegisterCommands() {
-	// Register user plugin register bot command.
-	p.RegisterCommand()
-	// Register user plugin get user bot command.
-	p.UserCommand()
-	// Register user plugin create bot command.
-	p.CreateCommand()
-	// Register user plugin use bot command.
-	p.UseCommand()
-	// Register user plugin update balance command.
-	p.UpdateBalanceCommand()
+// Getting all user plugin message components.
+func (p *UserPlugin) Components() []*bot.Component { return nil }
+
+// Getting all user plugin modals.
+func (p *UserPlugin) Modals() []*bot.Modal {
+	return []*bot.Modal{p.useModal(), p.reviewModal()}
 }
+
+// Getting the localized modal text by the message key.
+func (p *UserPlugin) localize(locale discordgo.Locale, key string) string {
+	return p.catalog.Message(locale, key, nil)
+}
+
+// Getting all user plugin event handlers.
+func (p *UserPlugin) Handlers() []any { return nil }
+
+// Starting the user plugin.
+func (p *UserPlugin) Start(ctx context.Context) error { return nil }
+
+// Stopping the user plugin.
+func (p *UserPlugin) Stop(ctx context.Context) error { return nil }
@@ -0,0 +1,425 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package user_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/command/user"
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/internal/service"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Testing config variables.
+var testConfig = config.NewStore(&config.Config{
+	Bot:  config.BotConfig{Color: 0xa735ed, LogChannel: "log"},
+	User: config.UserConfig{ReviewRole: "review", MinAge: time.Hour},
+	Response: config.ResponseConfig{
+		Ephemeral:    config.EphemeralConfig{Error: true, Info: true, Admin: true},
+		ErrorChannel: "errors",
+	},
+})
+
+// In-memory user service.
+type userService struct{ users map[string]domain.User }
+
+// Creating a new in-memory user service with the testing user.
+func newUserService() *userService {
+	return &userService{users: map[string]domain.User{
+		bottest.User.ID: {Id: bottest.User.ID, Balance: 100, Promo: "durudex"},
+	}}
+}
+
+// Creating a new user.
+func (s *userService) Create(ctx context.Context, user domain.User) error {
+	if _, ok := s.users[user.Id]; ok {
+		return &domain.Error{Code: domain.CodeAlreadyExists, Key: "errors.registered"}
+	}
+
+	s.users[user.Id] = user
+
+	return nil
+}
+
+// Getting a user.
+func (s *userService) Get(ctx context.Context, id string) (domain.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return domain.User{}, &domain.Error{Code: domain.CodeNotFound, Key: "errors.user-not-found"}
+	}
+
+	return user, nil
+}
+
+// Updating a user.
+func (s *userService) Update(ctx context.Context, user domain.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	current := s.users[user.Id]
+	current.Promo = user.Promo
+	s.users[user.Id] = current
+
+	return nil
+}
+
+// Using a user promo.
+func (s *userService) UsePromo(ctx context.Context, discordId, promo string) (int, error) {
+	switch promo {
+	case "broken":
+		return 0, fmt.Errorf("failed to use promo: %w", errors.New("connection refused"))
+	case "offline":
+		return 0, &domain.Error{Code: domain.CodeUnavailable, Key: "errors.unavailable", Err: errors.New("timeout")}
+	}
+
+	return 1000, nil
+}
+
+// Updating a user balance.
+func (s *userService) UpdateBalance(ctx context.Context, id string, amount int) error {
+	user, ok := s.users[id]
+	if !ok {
+		return &domain.Error{Code: domain.CodeNotFound, Key: "errors.user-not-exist"}
+	}
+
+	user.Balance += amount
+	s.users[id] = user
+
+	return nil
+}
+
+// In-memory audit service.
+type auditService struct{ events []domain.AuditEvent }
+
+// Recording an audit event.
+func (s *auditService) Record(event domain.AuditEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+// Delivering recorded audit events until the context is canceled.
+func (s *auditService) Run(ctx context.Context, deliver service.AuditDeliverer) {}
+
+// Test handling user plugin interactions.
+func TestUserPlugin(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	newcomer := &discordgo.User{ID: "110000000000000000", Username: "newcomer"}
+	target := &discordgo.User{ID: bottest.User.ID, Username: "durudex"}
+
+	// Creating a new interaction created by the reviewer.
+	review := func(i *discordgo.InteractionCreate) *discordgo.InteractionCreate {
+		i.Member.Roles = []string{"review"}
+		return i
+	}
+
+	// Creating a new update balance interaction.
+	updateBalance := func(amount float64) *discordgo.InteractionCreate {
+		i := bottest.Command("update-balance")
+		bottest.AddUserOption(i, "user", target)
+
+		data := i.Data.(discordgo.ApplicationCommandInteractionData)
+		data.Options = append(data.Options,
+			bottest.Option("amount", discordgo.ApplicationCommandOptionInteger, amount),
+			bottest.Option("reason", discordgo.ApplicationCommandOptionString, "Event winner"),
+		)
+		i.Data = data
+
+		return i
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name          string
+		interaction   func() *discordgo.InteractionCreate
+		wantType      discordgo.InteractionResponseType
+		wantEphemeral bool
+		wantContent   string
+		wantEmbed     string
+		wantTitle     string
+		wantEvents    int
+		wantIncidents int
+	}{
+		{
+			name: "Register",
+			interaction: func() *discordgo.InteractionCreate {
+				i := bottest.Command("register")
+				i.Member.User = newcomer
+				return i
+			},
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantContent: "You have successfully registered!",
+		},
+		{
+			name:          "Register Already Exists",
+			interaction:   func() *discordgo.InteractionCreate { return bottest.Command("register") },
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "You are registered.",
+		},
+		{
+			name: "User",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.Command("user")
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "**Token Balance:** 100",
+		},
+		{
+			name: "User Not Found",
+			interaction: func() *discordgo.InteractionCreate {
+				i := bottest.Command("user")
+				bottest.AddUserOption(i, "user", newcomer)
+				return i
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "User not found.",
+		},
+		{
+			name: "Create",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.Command("create", bottest.Option("promo", discordgo.ApplicationCommandOptionString, "promo"))
+			},
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantContent: "You created promo code `promo`",
+			wantEvents:  1,
+		},
+		{
+			name: "Create Invalid Promo",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.Command("create", bottest.Option("promo", discordgo.ApplicationCommandOptionString, "!"))
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "The promo code is invalid.",
+		},
+		{
+			name: "Use",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.Command("use", bottest.Option("promo", discordgo.ApplicationCommandOptionString, "promo"))
+			},
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantContent: "You used promo code `promo`",
+			wantEvents:  1,
+		},
+		{
+			name: "Use Internal Error",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.Command("use", bottest.Option("promo", discordgo.ApplicationCommandOptionString, "broken"))
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "incident ID",
+			wantIncidents: 1,
+		},
+		{
+			name: "Use Unavailable",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.Command("use", bottest.Option("promo", discordgo.ApplicationCommandOptionString, "offline"))
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "temporarily unavailable",
+		},
+		{
+			name:        "Use Modal",
+			interaction: func() *discordgo.InteractionCreate { return bottest.Command("use") },
+			wantType:    discordgo.InteractionResponseModal,
+			wantTitle:   "Use promo code",
+		},
+		{
+			name: "Use Modal Localized",
+			interaction: func() *discordgo.InteractionCreate {
+				i := bottest.Command("use")
+				i.Locale = discordgo.Ukrainian
+
+				return i
+			},
+			wantType:  discordgo.InteractionResponseModal,
+			wantTitle: "Використати промокод",
+		},
+		{
+			name: "Use Modal Submit",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.ModalSubmit("use", map[string]string{"promo": "promo"})
+			},
+			wantType:    discordgo.InteractionResponseChannelMessageWithSource,
+			wantContent: "You used promo code `promo`",
+			wantEvents:  1,
+		},
+		{
+			name:          "Update Balance",
+			interaction:   func() *discordgo.InteractionCreate { return review(updateBalance(50)) },
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantContent:   "You have updated the balance of user <@100000000000000000> on `50`",
+			wantEvents:    1,
+		},
+		{
+			name:          "Update Balance Without Role",
+			interaction:   func() *discordgo.InteractionCreate { return updateBalance(50) },
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "You do not have access to this command!",
+		},
+		{
+			name: "Update Balance In DM",
+			interaction: func() *discordgo.InteractionCreate {
+				i := updateBalance(50)
+				i.User, i.Member = i.Member.User, nil
+				return i
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "This command cannot be used in dm!",
+		},
+		{
+			name: "Promo Profile",
+			interaction: func() *discordgo.InteractionCreate {
+				return bottest.UserCommand("Promo profile", target)
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "**Own Promo:** durudex",
+		},
+		{
+			name: "Promo Profile Without Target",
+			interaction: func() *discordgo.InteractionCreate {
+				i := bottest.UserCommand("Promo profile", target)
+
+				data := i.Data.(discordgo.ApplicationCommandInteractionData)
+				data.Resolved = nil
+				i.Data = data
+
+				return i
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "The command target is not available.",
+		},
+		{
+			name: "Review Balance Without Author",
+			interaction: func() *discordgo.InteractionCreate {
+				return review(bottest.MessageCommand("Review balance", &discordgo.Message{ID: "1"}))
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "The command target is not available.",
+		},
+		{
+			name: "Review Balance",
+			interaction: func() *discordgo.InteractionCreate {
+				return review(bottest.MessageCommand("Review balance", &discordgo.Message{ID: "1", Author: target}))
+			},
+			wantType:  discordgo.InteractionResponseModal,
+			wantTitle: "Update balance",
+		},
+		{
+			name: "Review Balance Submit",
+			interaction: func() *discordgo.InteractionCreate {
+				return review(bottest.ModalSubmit(
+					bot.CustomID("balance", target.ID),
+					map[string]string{"amount": "-20", "reason": "Spam"},
+				))
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantContent:   "You have updated the balance of user <@100000000000000000> on `-20`",
+			wantEvents:    1,
+		},
+		{
+			name: "Review Balance Invalid Amount",
+			interaction: func() *discordgo.InteractionCreate {
+				return review(bottest.ModalSubmit(
+					bot.CustomID("balance", target.ID),
+					map[string]string{"amount": "many", "reason": "Spam"},
+				))
+			},
+			wantType:      discordgo.InteractionResponseChannelMessageWithSource,
+			wantEphemeral: true,
+			wantEmbed:     "The amount must be an integer.",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bot.New(&bot.BotConfig{Token: "123"})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			audit := &auditService{}
+
+			// Registering the user plugin.
+			if err := b.RegisterPlugin(user.NewUserPlugin(b, testConfig, newUserService(), audit, catalog)); err != nil {
+				t.Fatalf("error registering plugin: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction.
+			b.Handle(s, tt.interaction())
+
+			responses := s.Responses()
+			if len(responses) != 1 || responses[0].Type != tt.wantType {
+				t.Fatalf("error unexpected responses: %v", responses)
+			}
+
+			message := s.Message()
+
+			if ephemeral := message.Flags&discordgo.MessageFlagsEphemeral != 0; ephemeral != tt.wantEphemeral {
+				t.Errorf("error ephemeral are not similar: %t", ephemeral)
+			}
+			if message.Content != tt.wantContent {
+				t.Errorf("error content are not similar: %s", message.Content)
+			}
+			if tt.wantEmbed != "" && (len(message.Embeds) != 1 ||
+				!strings.Contains(message.Embeds[0].Description, tt.wantEmbed)) {
+				t.Errorf("error embed does not contain: %s", tt.wantEmbed)
+			}
+			if message.Title != tt.wantTitle {
+				t.Errorf("error title are not similar: %s", message.Title)
+			}
+			if len(audit.events) != tt.wantEvents {
+				t.Errorf("error unexpected audit events: %v", audit.events)
+			}
+			if incidents := s.ChannelMessages("errors"); len(incidents) != tt.wantIncidents {
+				t.Errorf("error unexpected incident messages: %d", len(incidents))
+			}
+		})
+	}
+}
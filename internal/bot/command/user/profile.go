@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package user
+
+import (
+	"context"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Promo profile bot user command.
+func (p *UserPlugin) profileCommand() *bot.Command {
+	return &bot.Command{
+		ApplicationCommand: p.profileCommandApplication(),
+		Handler:            p.profileCommandHandler,
+	}
+}
+
+// Promo profile command application.
+func (p *UserPlugin) profileCommandApplication() discordgo.ApplicationCommand {
+	return discordgo.ApplicationCommand{
+		Type: discordgo.UserApplicationCommand,
+		Name: "Promo profile",
+	}
+}
+
+// Promo profile command handler.
+func (p *UserPlugin) profileCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	target := bot.TargetUser(i)
+
+	// Checking is target user resolved.
+	if target == nil {
+		p.invalidTarget(r)
+		return
+	}
+
+	p.userProfile(ctx, r, target)
+}
+
+// Responding with the invalid context menu command target error, the target
+// is not resolved in malformed interactions.
+func (p *UserPlugin) invalidTarget(r *bot.Responder) {
+	// Send a interaction respond error message.
+	p.response.Error(r, &domain.Error{
+		Code:    domain.CodeInvalidArgument,
+		Message: p.catalog.Message(r.Locale(), "errors.invalid-target", nil),
+	})
+}
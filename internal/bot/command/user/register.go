@@ -30,13 +30,10 @@ import (
 )
 
 // Register bot command.
-func (p *UserPlugin) RegisterCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *UserPlugin) registerCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.registerCommandApplication(),
 		Handler:            p.registerCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
 	}
 }
 
@@ -49,53 +46,43 @@ func (p *UserPlugin) registerCommandApplication() discordgo.ApplicationCommand {
 }
 
 // Register command handler.
-func (p *UserPlugin) registerCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var author *discordgo.User
+func (p *UserPlugin) registerCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
 
-	// Checking where the command was use.
-	if i.Interaction.User == nil {
-		author = i.Interaction.Member.User
-	} else {
-		author = i.Interaction.User
-	}
+	author := bot.Author(i)
 
 	// Getting creating user timestamp.
 	createdAt, err := discordgo.SnowflakeTimestamp(author.ID)
 	if err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
 		return
 	}
 
 	// Checking min user account age.
-	if createdAt.Add(p.userCfg.MinAge).Unix() > time.Now().Unix() {
+	if createdAt.Add(p.cfg.Load().User.MinAge).Unix() > time.Now().Unix() {
 		// Send a interaction respond error message.
-		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "Your account is well new!",
-			},
-		}); err != nil {
-			log.Warn().Err(err).Msg("failed to send interaction respond error message")
-		}
+		p.response.Error(r, &domain.Error{
+			Code:    domain.CodeFailedPrecondition,
+			Message: p.catalog.Message(i.Locale, "register.too-new", nil),
+		})
 
 		return
 	}
 
 	// Creating a new user.
-	if err := p.service.Create(context.Background(), domain.User{Id: author.ID}); err != nil {
+	if err := p.service.Create(ctx, domain.User{Id: author.ID}); err != nil {
 		// Send a interaction respond error message.
-		if err := response.InteractionError(s, i, err); err != nil {
-			log.Warn().Err(err).Msg("failed to send interaction respond error message")
-		}
+		p.response.Error(r, err)
 
 		return
 	}
 
 	// Send a interaction respond message.
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: "You have successfully registered!",
-		},
+	if err := p.response.Respond(r, response.Success, &discordgo.InteractionResponseData{
+		Content: p.catalog.Message(i.Locale, "register.success", nil),
 	}); err != nil {
 		log.Warn().Err(err).Msg("failed to send interaction respond message")
 	}
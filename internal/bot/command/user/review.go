@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package user
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/middleware"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+// Review balance modal id pattern.
+const reviewModalID string = "balance:{user}"
+
+// Review balance modal.
+func (p *UserPlugin) reviewModal() *bot.Modal {
+	return &bot.Modal{
+		ModalID: reviewModalID,
+		Title:   "update-balance.modal.title",
+		Inputs: []discordgo.TextInput{
+			{
+				CustomID:    "amount",
+				Label:       "update-balance.modal.amount",
+				Style:       discordgo.TextInputShort,
+				Placeholder: "100",
+				Required:    true,
+				MaxLength:   10,
+			},
+			{
+				CustomID:  "reason",
+				Label:     "update-balance.modal.reason",
+				Style:     discordgo.TextInputParagraph,
+				Required:  true,
+				MaxLength: 1000,
+			},
+		},
+		Localize:    p.localize,
+		Handler:     p.reviewModalHandler,
+		Middlewares: []bot.Middleware{middleware.GuildOnly(p.catalog, p.response), middleware.ReviewRole(p.cfg, p.catalog, p.response)},
+	}
+}
+
+// Review balance bot message command.
+func (p *UserPlugin) reviewCommand() *bot.Command {
+	return &bot.Command{
+		ApplicationCommand: p.reviewCommandApplication(),
+		Handler:            p.reviewCommandHandler,
+		Middlewares:        []bot.Middleware{middleware.GuildOnly(p.catalog, p.response), middleware.ReviewRole(p.cfg, p.catalog, p.response)},
+	}
+}
+
+// Review balance command application.
+func (p *UserPlugin) reviewCommandApplication() discordgo.ApplicationCommand {
+	return discordgo.ApplicationCommand{
+		Type:                     discordgo.MessageApplicationCommand,
+		Name:                     "Review balance",
+		DefaultMemberPermissions: &UpdateCommandMemberPermission,
+	}
+}
+
+// Review balance command handler, it opens the update balance form for the
+// message author.
+func (p *UserPlugin) reviewCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	message := bot.TargetMessage(i)
+
+	// Checking is target message resolved.
+	if message == nil || message.Author == nil {
+		p.invalidTarget(p.bot.NewResponder(s, i))
+		return
+	}
+
+	// Opening the update balance modal.
+	if err := p.bot.OpenModal(s, i, bot.CustomID("balance", message.Author.ID)); err != nil {
+		log.Warn().Err(err).Msg("failed to open modal")
+	}
+}
+
+// Review balance modal handler.
+func (p *UserPlugin) reviewModalHandler(
+	ctx context.Context,
+	s bot.Session,
+	i *discordgo.InteractionCreate,
+	params bot.Params,
+	fields bot.ModalFields,
+) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	// Parsing the update amount.
+	amount, err := strconv.Atoi(fields.Get("amount"))
+	if err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, &domain.Error{
+			Code:    domain.CodeInvalidArgument,
+			Message: p.catalog.Message(i.Locale, "errors.invalid-amount", nil),
+		})
+
+		return
+	}
+
+	p.updateBalance(ctx, i, r, params.Get("user"), amount, fields.Get("reason"))
+}
@@ -19,8 +19,11 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
+	"github.com/durudex/discord-promo-bot/internal/bot/middleware"
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
+	"github.com/durudex/discord-promo-bot/internal/domain"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
 
 	"github.com/bwmarrin/discordgo"
@@ -29,14 +32,19 @@ import (
 
 var UpdateCommandMemberPermission int64 = discordgo.PermissionManageMessages
 
+// Update balance command options.
+type updateBalanceOptions struct {
+	User   *discordgo.User `option:"user,required" description:"User who needs to update the balance."`
+	Amount int             `option:"amount,required" description:"Quantity to be added or removed."`
+	Reason string          `option:"reason,required,maxlen=1000" description:"Reason for the change."`
+}
+
 // Update balance bot command
-func (p *UserPlugin) UpdateBalanceCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *UserPlugin) updateBalanceCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.updateBalanceCommandApplication(),
 		Handler:            p.updateBalanceCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
+		Middlewares:        []bot.Middleware{middleware.GuildOnly(p.catalog, p.response), middleware.ReviewRole(p.cfg, p.catalog, p.response)},
 	}
 }
 
@@ -46,118 +54,59 @@ func (p *UserPlugin) updateBalanceCommandApplication() discordgo.ApplicationComm
 		Name:                     "update-balance",
 		Description:              "The command updating the user balance.",
 		DefaultMemberPermissions: &UpdateCommandMemberPermission,
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionUser,
-				Name:        "user",
-				Description: "User who needs to update the balance.",
-				Required:    true,
-			},
-			{
-				Type:        discordgo.ApplicationCommandOptionInteger,
-				Name:        "amount",
-				Description: "Quantity to be added or removed.",
-				Required:    true,
-			},
-			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "reason",
-				Description: "Reason for the change.",
-				Required:    true,
-			},
-		},
+		Options:                  bot.MustOptions(updateBalanceOptions{}),
 	}
 }
 
 // Register command handler.
-func (p *UserPlugin) updateBalanceCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Check is command user in dm.
-	if i.Interaction.User != nil {
-		// Send a interaction respond message.
-		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "This command cannot be used in dm!",
-			},
-		}); err != nil {
-			log.Warn().Err(err).Msg("failed to send interaction respond message")
-		}
+func (p *UserPlugin) updateBalanceCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
 
-		return
-	}
+	var options updateBalanceOptions
 
-	// Checking if the user has the review role.
-	if !hasRole(i.Interaction.Member.Roles, p.userCfg.ReviewRole) {
-		// Send a interaction respond message.
-		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "You do not have access to this command!",
-			},
-		}); err != nil {
-			log.Warn().Err(err).Msg("failed to send interaction respond message")
-		}
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
 
 		return
 	}
 
+	p.updateBalance(ctx, i, r, options.User.ID, options.Amount, options.Reason)
+}
+
+// Updating the user balance and recording the audit event.
+func (p *UserPlugin) updateBalance(
+	ctx context.Context,
+	i *discordgo.InteractionCreate,
+	r *bot.Responder,
+	userID string,
+	amount int,
+	reason string,
+) {
 	// Updating the user balance.
-	if err := p.service.UpdateBalance(
-		context.Background(),
-		i.ApplicationCommandData().Options[0].UserValue(s).ID,
-		int(i.ApplicationCommandData().Options[1].IntValue()),
-	); err != nil {
-		log.Error().Err(err).Msg("failed to updating user balance")
+	if err := p.service.UpdateBalance(ctx, userID, amount); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
 	}
 
 	// Send a interaction respond message.
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf(
-				"You have updated the balance of user <@%s> on `%d`",
-				i.ApplicationCommandData().Options[0].UserValue(s).ID,
-				i.ApplicationCommandData().Options[1].IntValue(),
-			),
-		},
+	if err := p.response.Respond(r, response.Admin, &discordgo.InteractionResponseData{
+		Content: p.catalog.Message(i.Locale, "update-balance.success", response.BalanceData{User: userID, Amount: amount}),
 	}); err != nil {
 		log.Warn().Err(err).Msg("failed to send interaction respond message")
 	}
 
-	// Send bot log message.
-	if _, err := s.ChannelMessageSendEmbed(
-		p.botCfg.LogChannel,
-		&discordgo.MessageEmbed{
-			Author: &discordgo.MessageEmbedAuthor{
-				URL:     "https://discord.com/users/" + i.Interaction.Member.User.ID,
-				Name:    i.Interaction.Member.User.Username,
-				IconURL: i.Interaction.Member.User.AvatarURL("128x128"),
-			},
-			Description: fmt.Sprintf(
-				"User balance <@%s> has been updated on `%d`.",
-				i.ApplicationCommandData().Options[0].UserValue(s).ID,
-				i.ApplicationCommandData().Options[1].IntValue(),
-			),
-			Fields: []*discordgo.MessageEmbedField{
-				{
-					Name:  "Reason",
-					Value: "> " + i.ApplicationCommandData().Options[2].StringValue(),
-				},
-			},
-			Color: p.botCfg.Color,
-		},
-	); err != nil {
-		log.Warn().Err(err).Msg("failed to send channel message")
-	}
-}
+	event := audit.Event(i, domain.AuditBalanceAdjusted)
+	event.Target = userID
+	event.Amount = amount
+	event.Reason = reason
 
-// Check is target role in the list of roles.
-func hasRole(roles []string, target string) bool {
-	for _, role := range roles {
-		if role == target {
-			return true
-		}
+	// Recording the audit event.
+	if err := p.audit.Record(event); err != nil {
+		log.Error().Err(err).Msg("failed to record audit event")
 	}
-
-	return false
 }
@@ -19,23 +19,50 @@ package user
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
 	"github.com/durudex/discord-promo-bot/internal/bot/response"
+	"github.com/durudex/discord-promo-bot/internal/domain"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/rs/zerolog/log"
 )
 
+// Use promo code modal id.
+const useModalID string = "use"
+
+// Use command options.
+type useOptions struct {
+	Promo string `option:"promo" description:"Promo code, if not specified, a form will be opened."`
+}
+
+// Use promo code modal.
+func (p *UserPlugin) useModal() *bot.Modal {
+	return &bot.Modal{
+		ModalID: useModalID,
+		Title:   "use.modal.title",
+		Inputs: []discordgo.TextInput{
+			{
+				CustomID:    "promo",
+				Label:       "use.modal.promo",
+				Style:       discordgo.TextInputShort,
+				Placeholder: "durudex",
+				Required:    true,
+				MinLength:   3,
+				MaxLength:   12,
+			},
+		},
+		Localize: p.localize,
+		Handler:  p.useModalHandler,
+	}
+}
+
 // Use bot command.
-func (p *UserPlugin) UseCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *UserPlugin) useCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.useCommandApplication(),
 		Handler:            p.useCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
 	}
 }
 
@@ -44,70 +71,75 @@ func (p *UserPlugin) useCommandApplication() discordgo.ApplicationCommand {
 	return discordgo.ApplicationCommand{
 		Name:        "use",
 		Description: "The command use a user promo code.",
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "promo",
-				Description: "Promo code.",
-				Required:    true,
-			},
-		},
+		Options:     bot.MustOptions(useOptions{}),
 	}
 }
 
 // Use command handler.
-func (p *UserPlugin) useCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var author *discordgo.User
-
-	// Checking where the command was use.
-	if i.Interaction.User == nil {
-		author = i.Interaction.Member.User
-	} else {
-		author = i.Interaction.User
+func (p *UserPlugin) useCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	var options useOptions
+
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(p.bot.NewResponder(s, i), err)
+
+		return
 	}
 
+	// Checking is promo code specified.
+	if options.Promo == "" {
+		// Opening the use promo code modal.
+		if err := p.bot.OpenModal(s, i, useModalID); err != nil {
+			log.Warn().Err(err).Msg("failed to open modal")
+		}
+
+		return
+	}
+
+	p.usePromo(ctx, s, i, options.Promo)
+}
+
+// Use modal handler.
+func (p *UserPlugin) useModalHandler(
+	ctx context.Context,
+	s bot.Session,
+	i *discordgo.InteractionCreate,
+	params bot.Params,
+	fields bot.ModalFields,
+) {
+	p.usePromo(ctx, s, i, fields.Get("promo"))
+}
+
+// Using a promo code.
+func (p *UserPlugin) usePromo(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate, promo string) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	author := bot.Author(i)
+
 	// Use a promo code.
-	reward, err := p.service.UsePromo(
-		context.Background(),
-		author.ID,
-		i.ApplicationCommandData().Options[0].StringValue(),
-	)
+	reward, err := p.service.UsePromo(ctx, author.ID, promo)
 	if err != nil {
 		// Send a interaction respond error message.
-		if err := response.InteractionError(s, i, err); err != nil {
-			log.Warn().Err(err).Msg("failed to send interaction respond error message")
-		}
+		p.response.Error(r, err)
 
 		return
 	}
 
 	// Send a interaction respond message.
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("You used promo code `%s`", i.ApplicationCommandData().Options[0].StringValue()),
-		},
+	if err := p.response.Respond(r, response.Success, &discordgo.InteractionResponseData{
+		Content: p.catalog.Message(i.Locale, "use.success", response.PromoData{Promo: promo, Reward: reward}),
 	}); err != nil {
 		log.Warn().Err(err).Msg("failed to send interaction respond message")
 	}
 
-	// Send bot log message.
-	if _, err := s.ChannelMessageSendEmbed(
-		p.botCfg.LogChannel,
-		&discordgo.MessageEmbed{
-			Author: &discordgo.MessageEmbedAuthor{
-				URL:     "https://discord.com/users/" + author.ID,
-				Name:    author.Username,
-				IconURL: author.AvatarURL("128x128"),
-			},
-			Description: fmt.Sprintf(
-				"User used the promo code `%s` and received %d DUR.",
-				i.ApplicationCommandData().Options[0].StringValue(),
-				reward,
-			),
-			Color: p.botCfg.Color,
-		},
-	); err != nil {
-		log.Warn().Err(err).Msg("failed to send channel message")
+	event := audit.Event(i, domain.AuditPromoUsed)
+	event.Promo = promo
+	event.Amount = reward
+
+	// Recording the audit event.
+	if err := p.audit.Record(event); err != nil {
+		log.Error().Err(err).Msg("failed to record audit event")
 	}
 }
@@ -19,7 +19,6 @@ package user
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/durudex/discord-promo-bot/internal/bot/response"
 	"github.com/durudex/discord-promo-bot/pkg/bot"
@@ -28,14 +27,16 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// User command options.
+type userOptions struct {
+	User *discordgo.User `option:"user" description:"Get information about the specified user."`
+}
+
 // User bot command.
-func (p *UserPlugin) UserCommand() {
-	// Registering a new discord application command.
-	if err := p.bot.RegisterCommand(&bot.Command{
+func (p *UserPlugin) userCommand() *bot.Command {
+	return &bot.Command{
 		ApplicationCommand: p.userCommandApplication(),
 		Handler:            p.userCommandHandler,
-	}); err != nil {
-		log.Error().Err(err).Msg("failed to register command")
 	}
 }
 
@@ -44,56 +45,53 @@ func (p *UserPlugin) userCommandApplication() discordgo.ApplicationCommand {
 	return discordgo.ApplicationCommand{
 		Name:        "user",
 		Description: "The command getting public information about the user.",
-		Options: []*discordgo.ApplicationCommandOption{
-			{
-				Type:        discordgo.ApplicationCommandOptionUser,
-				Name:        "user",
-				Description: "Get information about the specified user.",
-				Required:    false,
-			},
-		},
+		Options:     bot.MustOptions(userOptions{}),
 	}
 }
 
 // User command handler.
-func (p *UserPlugin) userCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var author *discordgo.User
-
-	// Setting the author.
-	if i.ApplicationCommandData().Options == nil {
-		// Checking where the command was use.
-		if i.Interaction.User == nil {
-			author = i.Interaction.Member.User
-		} else {
-			author = i.Interaction.User
-		}
-	} else {
-		author = i.ApplicationCommandData().Options[0].UserValue(s)
+func (p *UserPlugin) userCommandHandler(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+	// Creating a new interaction responder.
+	r := p.bot.NewResponder(s, i)
+
+	var options userOptions
+
+	// Binding the command options.
+	if err := bot.BindOptions(i, &options); err != nil {
+		// Send a interaction respond error message.
+		p.response.Error(r, err)
+
+		return
+	}
+
+	author := bot.Author(i)
+
+	// Checking is user specified.
+	if options.User != nil {
+		author = options.User
 	}
 
+	p.userProfile(ctx, r, author)
+}
+
+// Responding with the user public information.
+func (p *UserPlugin) userProfile(ctx context.Context, r *bot.Responder, author *discordgo.User) {
 	// Getting a user.
-	user, err := p.service.Get(context.Background(), author.ID)
+	user, err := p.service.Get(ctx, author.ID)
 	if err != nil {
 		// Send a interaction respond error message.
-		if err := response.InteractionError(s, i, err); err != nil {
-			log.Warn().Err(err).Msg("failed to getting user")
-		}
+		p.response.Error(r, err)
 
 		return
 	}
 
 	// Send a interaction respond message.
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title: author.Username,
-					Description: fmt.Sprintf("**Token Balance:** %d\n", user.Balance) +
-						fmt.Sprintf("**Used Promo:** %s\n", user.Used) +
-						fmt.Sprintf("**Own Promo:** %s\n", user.Promo),
-					Color: p.botCfg.Color,
-				},
+	if err := p.response.Respond(r, response.Info, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       author.Username,
+				Description: p.catalog.Message(r.Locale(), "user.profile", user),
+				Color:       p.cfg.Load().Bot.Color,
 			},
 		},
 	}); err != nil {
@@ -0,0 +1,117 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+// Interaction logging middleware.
+func Logger() bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+			start := time.Now()
+
+			next(ctx, s, i)
+
+			log.Debug().
+				Str("type", i.Type.String()).
+				Str("name", interactionName(i)).
+				Str("user", bot.Author(i).ID).
+				Dur("duration", time.Since(start)).
+				Msg("interaction handled")
+		}
+	}
+}
+
+// Middleware that rejects interactions created in dm.
+func GuildOnly(c *locale.Catalog, res *response.Response) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+			// Check is interaction created in dm.
+			if i.Interaction.Member == nil {
+				// Send a interaction respond error message.
+				res.MessageError(s, i, &domain.Error{
+					Code:    domain.CodeFailedPrecondition,
+					Message: c.Message(i.Locale, "errors.guild-only", nil),
+				})
+
+				return
+			}
+
+			next(ctx, s, i)
+		}
+	}
+}
+
+// Middleware that rejects interactions from members without the review role,
+// interactions created in dm are rejected as well.
+func ReviewRole(cfg *config.Store, c *locale.Catalog, res *response.Response) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+			// Checking if the user is a member with the review role.
+			if i.Interaction.Member == nil || !hasRole(i.Interaction.Member.Roles, cfg.Load().User.ReviewRole) {
+				// Send a interaction respond error message.
+				res.MessageError(s, i, &domain.Error{
+					Code:    domain.CodePermissionDenied,
+					Message: c.Message(i.Locale, "errors.access-denied", nil),
+				})
+
+				return
+			}
+
+			next(ctx, s, i)
+		}
+	}
+}
+
+// Getting the name of the interaction command or custom id.
+func interactionName(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand, discordgo.InteractionApplicationCommandAutocomplete:
+		path, _ := bot.CommandPath(i.ApplicationCommandData())
+		return path
+	case discordgo.InteractionMessageComponent:
+		return i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return i.ModalSubmitData().CustomID
+	default:
+		return ""
+	}
+}
+
+// Check is target role in the list of roles.
+func hasRole(roles []string, target string) bool {
+	for _, role := range roles {
+		if role == target {
+			return true
+		}
+	}
+
+	return false
+}
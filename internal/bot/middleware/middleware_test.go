@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/middleware"
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test rejecting interactions from members without the review role.
+func TestReviewRole(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	cfg := config.NewStore(&config.Config{User: config.UserConfig{ReviewRole: "review"}})
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		member      *discordgo.Member
+		wantHandled bool
+	}{
+		{name: "OK", member: &discordgo.Member{User: bottest.User, Roles: []string{"review"}}, wantHandled: true},
+		{name: "Without Role", member: &discordgo.Member{User: bottest.User}},
+		{name: "DM"},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled bool
+
+			i := bottest.Command("update-balance")
+			i.Member = tt.member
+			if tt.member == nil {
+				i.User = bottest.User
+			}
+
+			s := bottest.NewSession()
+
+			// Calling the handler with the review role middleware.
+			middleware.ReviewRole(cfg, catalog, response.New(cfg, catalog))(
+				func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) { handled = true },
+			)(context.Background(), s, i)
+
+			if handled != tt.wantHandled {
+				t.Errorf("error handled are not similar: %t", handled)
+			}
+			if !tt.wantHandled && len(s.Responses()) != 1 {
+				t.Errorf("error unexpected responses: %v", s.Responses())
+			}
+		})
+	}
+}
@@ -18,40 +18,237 @@
 package response
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/durudex/discord-promo-bot/internal/bot/audit"
 	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
 )
 
-// Discord interaction error message.
-func InteractionError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) error {
-	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: errorHandler(err),
+// Error reply embed colors.
+const (
+	errorColor   int = 0xed4245
+	warningColor int = 0xfee75c
+	noticeColor  int = 0x5865f2
+)
+
+// Maximum length of the discord embed description in characters.
+const maxDescriptionLength int = 4096
+
+// Domain error reply structure.
+type errorReply struct {
+	// Reply type, it defines the reply ephemerality.
+	Type Type
+	// Reply embed color.
+	Color int
+	// Localized message key, if it is specified, it is used instead of the
+	// error message.
+	Key string
+	// Internal error, it is reported with an incident id.
+	Internal bool
+}
+
+// Domain error replies by error code. Errors with unknown codes are replied
+// as internal errors.
+var errorReplies = map[domain.Code]errorReply{
+	domain.CodeInternal:           {Type: Error, Color: errorColor, Internal: true},
+	domain.CodeNotFound:           {Type: Error, Color: warningColor},
+	domain.CodeAlreadyExists:      {Type: Error, Color: warningColor},
+	domain.CodeInvalidArgument:    {Type: Error, Color: warningColor},
+	domain.CodePermissionDenied:   {Type: Error, Color: errorColor},
+	domain.CodeFailedPrecondition: {Type: Error, Color: warningColor},
+	domain.CodeResourceExhausted:  {Type: Info, Color: noticeColor},
+	domain.CodeRateLimited:        {Type: Error, Color: warningColor, Key: "errors.rate-limited"},
+	domain.CodeUnavailable:        {Type: Error, Color: errorColor, Key: "errors.unavailable"},
+}
+
+// Sending the interaction error message, sending errors are logged. It is
+// used by handlers instead of the interaction error message.
+func (r *Response) Error(responder *bot.Responder, err error) {
+	if err := r.InteractionError(responder, err); err != nil {
+		log.Warn().Err(err).Msg("failed to send interaction respond error message")
+	}
+}
+
+// Discord interaction error message. Domain errors are sent by the error
+// replies mapping, internal errors get an incident id which is shown to the
+// user, logged with the full error chain and posted to the error log channels.
+func (r *Response) InteractionError(responder *bot.Responder, err error) error {
+	e := domainError(err)
+	reply := replyOf(e)
+
+	// Checking is error can be shown to the user.
+	if !reply.Internal {
+		// Checking is error has a cause, for example a database error.
+		if e.Err != nil {
+			log.Warn().Err(err).Msg("interaction error")
+		}
+
+		return r.Respond(responder, reply.Type, r.errorData(responder.Locale(), reply, e))
+	}
+
+	incident := newIncidentID()
+	chain := errorChain(err)
+	i := responder.Interaction()
+	author := bot.Author(&discordgo.InteractionCreate{Interaction: i})
+
+	log.Error().
+		Err(err).
+		Strs("chain", chain).
+		Str("incident", incident).
+		Str("interaction", i.ID).
+		Str("user", author.ID).
+		Msg("internal bot error")
+
+	cfg := r.cfg.Load()
+
+	// Send an incident message to the error log channels.
+	for _, channel := range audit.Channels(
+		cfg.Bot.LogRoutes,
+		domain.AuditEvent{Type: domain.AuditError},
+		cfg.Response.ErrorChannel,
+	) {
+		if _, err := responder.Session().ChannelMessageSendEmbed(channel, &discordgo.MessageEmbed{
+			Title:       "Incident " + incident,
+			Description: incidentDescription(chain),
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: "User", Value: "<@" + author.ID + ">", Inline: true},
+				{Name: "Interaction", Value: i.Type.String() + " " + i.ID, Inline: true},
+			},
+			Color:     errorColor,
+			Timestamp: time.Now().Format(time.RFC3339),
+		}); err != nil {
+			log.Warn().Err(err).Msg("failed to send channel message")
+		}
+	}
+
+	return r.Respond(responder, reply.Type, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       r.catalog.Message(i.Locale, "errors.internal", nil),
+				Description: r.catalog.Message(i.Locale, "errors.incident", IncidentData{ID: incident}),
+				Color:       reply.Color,
+			},
 		},
 	})
 }
 
-// Discord bot response error handler.
-func errorHandler(err error) string {
-	var e *domain.Error
-
-	// Check if error is a domain.Error.
-	if errors.As(err, &e) {
-		switch e.Code {
-		case domain.CodeNotFound:
-			return e.Message
-		case domain.CodeAlreadyExists:
-			return e.Message
-		case domain.CodeInvalidArgument:
-			return e.Message
-		case domain.CodeInternal:
-			return "Internal bot error"
-		}
+// Sending the interaction domain error message directly, it is used when there
+// is no interaction responder, for example in middlewares.
+func (r *Response) MessageError(s bot.Session, i *discordgo.InteractionCreate, err error) {
+	e := domainError(err)
+	reply := replyOf(e)
+
+	// Internal errors are not reported without the interaction responder.
+	if reply.Internal {
+		log.Error().Err(err).Msg("internal bot error")
+
+		e = &domain.Error{Code: domain.CodeInternal}
+		reply.Key = "errors.internal"
+	}
+
+	data := r.errorData(i.Locale, reply, e)
+	data.Flags = r.Flags(reply.Type)
+
+	// Send a interaction respond error message.
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	}); err != nil {
+		log.Warn().Err(err).Msg("failed to send interaction respond error message")
+	}
+}
+
+// Getting the interaction response data of the domain error reply.
+func (r *Response) errorData(locale discordgo.Locale, reply errorReply, e *domain.Error) *discordgo.InteractionResponseData {
+	message := e.Message
+
+	// Checking is localized message specified, the error message key is used
+	// before the reply message.
+	switch {
+	case e.Key != "":
+		message = r.catalog.Message(locale, e.Key, e.Data)
+	case reply.Key != "":
+		message = r.catalog.Message(locale, reply.Key, nil)
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{{Description: message, Color: reply.Color}},
+	}
+}
+
+// Getting the domain error from the error chain. Discord rate limit errors are
+// converted to rate limited errors, other errors are converted to internal.
+func domainError(err error) *domain.Error {
+	var (
+		e         *domain.Error
+		rateLimit *discordgo.RateLimitError
+	)
+
+	switch {
+	case errors.As(err, &e):
+		return e
+	case errors.As(err, &rateLimit):
+		return &domain.Error{Code: domain.CodeRateLimited, Err: err}
+	default:
+		return &domain.Error{Code: domain.CodeInternal, Err: err}
+	}
+}
+
+// Getting the domain error reply.
+func replyOf(e *domain.Error) errorReply {
+	if reply, ok := errorReplies[e.Code]; ok {
+		return reply
+	}
+
+	return errorReplies[domain.CodeInternal]
+}
+
+// Getting messages of all errors in the error chain.
+func errorChain(err error) []string {
+	chain := make([]string, 0, 1)
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, err.Error())
+	}
+
+	return chain
+}
+
+// Getting the incident embed description with the error chain in a code block,
+// the chain is truncated to the embed description length.
+func incidentDescription(chain []string) string {
+	const (
+		fence     = "```\n"
+		ellipsis  = "\n..."
+		available = maxDescriptionLength - len(fence)*2 - len(ellipsis)
+	)
+
+	text := []rune(strings.Join(chain, "\n"))
+
+	// Checking is error chain too long.
+	if len(text) > available+len(ellipsis) {
+		text = append(text[:available], []rune(ellipsis)...)
+	}
+
+	return fence + string(text) + "\n```"
+}
+
+// Generating a new incident id.
+func newIncidentID() string {
+	id := make([]byte, 6)
+
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
 	}
 
-	return "Internal bot error"
+	return hex.EncodeToString(id)
 }
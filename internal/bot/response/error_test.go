@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package response
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test getting the interaction response data of the domain error reply.
+func TestResponse_ErrorData(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	r := New(nil, catalog)
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		err  *domain.Error
+		want string
+	}{
+		{
+			name: "Error Key",
+			err:  &domain.Error{Code: domain.CodeRateLimited, Key: "errors.cooldown", Data: CooldownData{Seconds: 5}},
+			want: "You are doing that too often, try again in 5 seconds.",
+		},
+		{
+			name: "Reply Key",
+			err:  &domain.Error{Code: domain.CodeRateLimited},
+			want: "Discord is limiting the bot requests, try again later.",
+		},
+		{
+			name: "Message",
+			err:  &domain.Error{Code: domain.CodeNotFound, Message: "Not found."},
+			want: "Not found.",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.errorData(discordgo.EnglishUS, replyOf(tt.err), tt.err)
+
+			if got.Embeds[0].Description != tt.want {
+				t.Errorf("error message are not similar: %s", got.Embeds[0].Description)
+			}
+		})
+	}
+}
+
+// Test getting the incident embed description.
+func TestIncidentDescription(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name      string
+		chain     []string
+		want      string
+		wantLen   int
+		wantTrunc bool
+	}{
+		{
+			name:    "OK",
+			chain:   []string{"failed to save", "connection refused"},
+			want:    "```\nfailed to save\nconnection refused\n```",
+			wantLen: 41,
+		},
+		{
+			name:    "Max Length",
+			chain:   []string{strings.Repeat("є", 4088)},
+			wantLen: 4096,
+		},
+		{
+			name:      "Too Long",
+			chain:     []string{strings.Repeat("є", 3000), strings.Repeat("є", 3000)},
+			wantLen:   4096,
+			wantTrunc: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := incidentDescription(tt.chain)
+
+			if tt.want != "" && got != tt.want {
+				t.Errorf("error description are not similar: %s", got)
+			}
+			if length := utf8.RuneCountInString(got); length != tt.wantLen {
+				t.Errorf("error description length are not similar: %d", length)
+			}
+			if strings.HasSuffix(got, "\n...\n```") != tt.wantTrunc {
+				t.Errorf("error description truncation are not similar: %s", got[len(got)-10:])
+			}
+		})
+	}
+}
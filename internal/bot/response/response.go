@@ -0,0 +1,85 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package response
+
+import (
+	"github.com/durudex/discord-promo-bot/internal/config"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord bot reply type.
+type Type int
+
+const (
+	// Error reply, for example a validation or an internal error.
+	Error Type = iota
+	// Information reply, for example a user balance or an epoch.
+	Info
+	// Administrative action confirmation reply.
+	Admin
+	// Successful user action reply, for example a new promo code.
+	Success
+)
+
+// Discord bot response structure.
+type Response struct {
+	// Config store.
+	cfg *config.Store
+	// Message catalog.
+	catalog *locale.Catalog
+}
+
+// Creating a new discord bot response.
+func New(cfg *config.Store, catalog *locale.Catalog) *Response {
+	return &Response{cfg: cfg, catalog: catalog}
+}
+
+// Getting the message flags of the reply type.
+func (r *Response) Flags(t Type) discordgo.MessageFlags {
+	var (
+		ephemeral bool
+		cfg       = r.cfg.Load().Response.Ephemeral
+	)
+
+	switch t {
+	case Error:
+		ephemeral = cfg.Error
+	case Info:
+		ephemeral = cfg.Info
+	case Admin:
+		ephemeral = cfg.Admin
+	case Success:
+		ephemeral = cfg.Success
+	}
+
+	if ephemeral {
+		return discordgo.MessageFlagsEphemeral
+	}
+
+	return 0
+}
+
+// Sending the interaction response with the reply type flags.
+func (r *Response) Respond(responder *bot.Responder, t Type, data *discordgo.InteractionResponseData) error {
+	data.Flags |= r.Flags(t)
+
+	return responder.Respond(data)
+}
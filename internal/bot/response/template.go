@@ -0,0 +1,102 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package response
+
+import "github.com/durudex/discord-promo-bot/internal/domain"
+
+// Cooldown reply template data.
+type CooldownData struct {
+	// Seconds until the command can be used again.
+	Seconds int
+}
+
+// Internal error reply template data.
+type IncidentData struct {
+	// Incident id.
+	ID string
+}
+
+// Promo code reply template data.
+type PromoData struct {
+	// Promo code.
+	Promo string
+	// Promo code reward, it is specified only when the promo code is used.
+	Reward int
+}
+
+// Balance update reply template data.
+type BalanceData struct {
+	// Discord id of the user whose balance is updated.
+	User string
+	// Balance update amount.
+	Amount int
+}
+
+// Reply and log event templates data types by message key. Message templates
+// are validated with these types at startup, messages with nil data do not
+// use any data.
+var Templates = map[string]any{
+	"errors.internal":             nil,
+	"errors.incident":             IncidentData{},
+	"errors.guild-only":           nil,
+	"errors.access-denied":        nil,
+	"errors.invalid-amount":       nil,
+	"errors.invalid-target":       nil,
+	"errors.cooldown":             CooldownData{},
+	"errors.rate-limited":         nil,
+	"errors.unavailable":          nil,
+	"errors.invalid-promo":        nil,
+	"errors.registered":           nil,
+	"errors.user-not-found":       nil,
+	"errors.user-not-exist":       nil,
+	"errors.promo-exists":         nil,
+	"errors.promo-created":        nil,
+	"errors.promo-not-found":      nil,
+	"errors.own-promo":            nil,
+	"errors.promo-used":           nil,
+	"errors.epoch-not-found":      nil,
+	"errors.epochs-limit":         domain.LimitData{},
+	"errors.rewards-over":         nil,
+	"errors.last-epoch":           nil,
+	"errors.negative-limit":       nil,
+	"errors.invalid-reward":       nil,
+	"register.too-new":            nil,
+	"register.success":            nil,
+	"user.profile":                domain.User{},
+	"create.success":              PromoData{},
+	"use.success":                 PromoData{},
+	"use.modal.title":             nil,
+	"use.modal.promo":             nil,
+	"update-balance.success":      BalanceData{},
+	"update-balance.modal.title":  nil,
+	"update-balance.modal.amount": nil,
+	"update-balance.modal.reason": nil,
+	"audit.promo.created":         domain.AuditEvent{},
+	"audit.promo.used":            domain.AuditEvent{},
+	"audit.balance.adjusted":      domain.AuditEvent{},
+	"audit.epoch.advanced":        domain.AuditEvent{},
+	"audit.epoch.updated":         domain.AuditEvent{},
+	"audit.reason":                nil,
+	"epoch.title":                 domain.Monitor{},
+	"epoch.info":                  domain.Monitor{},
+	"epoch.choice":                domain.Monitor{},
+	"epoch-admin.advance":         domain.Monitor{},
+	"epoch-admin.set-limit":       domain.Monitor{},
+	"epoch-admin.set-reward":      domain.Monitor{},
+	"epoch-admin.reset":           domain.Monitor{},
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package response_test
+
+import (
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/internal/bot/response"
+	"github.com/durudex/discord-promo-bot/internal/locale"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test validating reply and log event templates of the bot catalogs.
+func TestTemplates(t *testing.T) {
+	// Loading message catalogs.
+	catalog, err := locale.Load("../../../configs/locales", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	// Validating message templates.
+	if err := catalog.Validate(response.Templates); err != nil {
+		t.Errorf("error validating message templates: %s", err.Error())
+	}
+}
@@ -18,10 +18,18 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/durudex/discord-promo-bot/internal/domain"
+
+	"github.com/fsnotify/fsnotify"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
@@ -29,6 +37,12 @@ import (
 // Default config path.
 const defaultConfigPath string = "configs/main"
 
+// Promo epoch sources.
+const (
+	EpochSourceConfig   string = "config"
+	EpochSourceDatabase string = "database"
+)
+
 type (
 	// Config variables.
 	Config struct {
@@ -36,15 +50,48 @@ type (
 		Database DatabaseConfig `mapstructure:"database"`
 		User     UserConfig     `mapstructure:"user"`
 		Promo    PromoConfig    `mapstructure:"promo"`
+		Locale   LocaleConfig   `mapstructure:"locale"`
+		Plugins  []PluginConfig `mapstructure:"plugins"`
+		Response ResponseConfig `mapstructure:"response"`
 	}
 
 	// Discord bot config variables.
 	BotConfig struct {
-		Color      int    `mapstructure:"color"`
-		LogChannel string `mapstructure:"log-channel"`
+		Color      int                       `mapstructure:"color"`
+		LogChannel string                    `mapstructure:"log-channel"`
+		Guilds     []string                  `mapstructure:"guilds"`
+		Global     bool                      `mapstructure:"global"`
+		Timeout    time.Duration             `mapstructure:"timeout"`
+		DeferAfter time.Duration             `mapstructure:"defer-after"`
+		HTTP       HTTPConfig                `mapstructure:"http"`
+		Cooldowns  map[string]CooldownConfig `mapstructure:"cooldowns"`
+		LogRoutes  []LogRouteConfig          `mapstructure:"log-routes"`
 		Token      string
 	}
 
+	// Log channel route config variables. Events of the route types are sent
+	// to the route channels, events with an amount less than the min amount
+	// in absolute value are skipped.
+	LogRouteConfig struct {
+		Events    []string `mapstructure:"events"`
+		Channels  []string `mapstructure:"channels"`
+		MinAmount int      `mapstructure:"min-amount"`
+	}
+
+	// Discord application command cooldown config variables.
+	CooldownConfig struct {
+		User    time.Duration `mapstructure:"user"`
+		Guild   time.Duration `mapstructure:"guild"`
+		Command time.Duration `mapstructure:"command"`
+	}
+
+	// Discord interactions endpoint config variables. If the address is not
+	// specified, interactions are received over the gateway.
+	HTTPConfig struct {
+		Addr      string `mapstructure:"addr"`
+		PublicKey string
+	}
+
 	// Database config variables.
 	DatabaseConfig struct {
 		Mongodb MongodbConfig `mapstructure:"mongodb"`
@@ -65,14 +112,97 @@ type (
 		MinAge     time.Duration `mapstructure:"min-age"`
 	}
 
-	// Promo config variables.
+	// Promo config variables. Epochs are taken from the config or from the
+	// database depending on the epoch source.
 	PromoConfig struct {
 		AutoSaveTTL time.Duration `mapstructure:"autosave-ttl"`
+		EpochSource string        `mapstructure:"epoch-source"`
+		Epochs      []EpochConfig `mapstructure:"epochs"`
+	}
+
+	// Promo epoch config variables, epochs are ordered as in the list.
+	EpochConfig struct {
+		Reward     int `mapstructure:"reward"`
+		UsageLimit int `mapstructure:"usage-limit"`
+	}
+
+	// Bot plugin config variables, disabled plugin commands are not
+	// registered.
+	PluginConfig struct {
+		Name     string   `mapstructure:"name"`
+		Disabled []string `mapstructure:"disabled"`
+	}
+
+	// Response config variables.
+	ResponseConfig struct {
+		Ephemeral    EphemeralConfig `mapstructure:"ephemeral"`
+		ErrorChannel string          `mapstructure:"error-channel"`
+	}
+
+	// Ephemeral reply types config variables, ephemeral replies are visible
+	// only to the user who created the interaction.
+	EphemeralConfig struct {
+		Error   bool `mapstructure:"error"`
+		Info    bool `mapstructure:"info"`
+		Admin   bool `mapstructure:"admin"`
+		Success bool `mapstructure:"success"`
+	}
+
+	// Locale config variables.
+	LocaleConfig struct {
+		Path    string `mapstructure:"path"`
+		Default string `mapstructure:"default"`
 	}
 )
 
+// Config store structure, it holds the current config which is replaced
+// atomically when the config file is reloaded.
+type Store struct{ value atomic.Value }
+
+// Creating a new config store.
+func NewStore(cfg *Config) *Store {
+	s := &Store{}
+	s.value.Store(cfg)
+
+	return s
+}
+
+// Getting the current config. The config must not be modified, handlers
+// should load it once to work with a consistent snapshot.
+func (s *Store) Load() *Config {
+	return s.value.Load().(*Config)
+}
+
+// Reloading the config file. Only the bot color, bot log channel and user
+// variables are applied, changes of other variables require a restart.
+func (s *Store) Reload() error {
+	// Read config file.
+	if err := viper.ReadInConfig(); err != nil {
+		return err
+	}
+
+	cfg, err := load()
+	if err != nil {
+		return err
+	}
+
+	current := s.Load()
+
+	// Checking is variables that require a restart changed.
+	if keys := restartChanges(current, applyReloadable(*cfg, current)); len(keys) != 0 {
+		log.Warn().Strs("keys", keys).Msg("some config changes require a restart")
+	}
+
+	next := applyReloadable(*current, cfg)
+	s.value.Store(next)
+
+	log.Info().Strs("changes", changes(current, next)).Msg("config reloaded")
+
+	return nil
+}
+
 // Initialize config.
-func Init() (*Config, error) {
+func Init() (*Store, error) {
 	log.Debug().Msg("Initialize config...")
 
 	// Parsing specified when starting the config file.
@@ -80,6 +210,27 @@ func Init() (*Config, error) {
 		return nil, err
 	}
 
+	cfg, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(cfg), nil
+}
+
+// Watching the config file, the config is reloaded on changes.
+func (s *Store) Watch() {
+	// Reloading config on config file changes.
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		if err := s.Reload(); err != nil {
+			log.Error().Err(err).Msg("failed to reload config")
+		}
+	})
+	viper.WatchConfig()
+}
+
+// Loading and validating config variables.
+func load() (*Config, error) {
 	var cfg Config
 
 	// Unmarshal config keys.
@@ -90,9 +241,140 @@ func Init() (*Config, error) {
 	// Set env configurations.
 	setFromEnv(&cfg)
 
+	// Validating config variables.
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// Validating config variables.
+func (c *Config) Validate() error {
+	switch {
+	case c.Bot.Color < 0 || c.Bot.Color > 0xffffff:
+		return errors.New("bot.color must be a rgb color")
+	case c.Bot.LogChannel == "":
+		return errors.New("bot.log-channel is required")
+	case c.User.MinAge < 0:
+		return errors.New("user.min-age must not be negative")
+	case c.Promo.AutoSaveTTL <= 0:
+		return errors.New("promo.autosave-ttl must be positive")
+	case c.Promo.EpochSource != EpochSourceConfig && c.Promo.EpochSource != EpochSourceDatabase:
+		return fmt.Errorf("promo.epoch-source must be %s or %s", EpochSourceConfig, EpochSourceDatabase)
+	}
+
+	// Checking is epochs taken from the config.
+	if c.Promo.EpochSource == EpochSourceConfig {
+		if err := domain.ValidateEpochs(c.Promo.EpochTable()); err != nil {
+			return fmt.Errorf("promo.epochs: %w", err)
+		}
+	}
+
+	for _, route := range c.Bot.LogRoutes {
+		if err := route.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validating the log channel route.
+func (r *LogRouteConfig) Validate() error {
+	switch {
+	case len(r.Events) == 0:
+		return errors.New("bot.log-routes events are required")
+	case len(r.Channels) == 0:
+		return errors.New("bot.log-routes channels are required")
+	case r.MinAmount < 0:
+		return errors.New("bot.log-routes min-amount must not be negative")
+	}
+
+	for _, event := range r.Events {
+		if !domain.IsLogEvent(event) {
+			return fmt.Errorf("bot.log-routes unknown event %s", event)
+		}
+	}
+
+	return nil
+}
+
+// Getting the promo epochs table of the config epochs.
+func (c *PromoConfig) EpochTable() []domain.Monitor {
+	epochs := make([]domain.Monitor, 0, len(c.Epochs))
+
+	for i, epoch := range c.Epochs {
+		epochs = append(epochs, domain.Monitor{Id: i + 1, Reward: epoch.Reward, UsageLimit: epoch.UsageLimit})
+	}
+
+	return epochs
+}
+
+// Applying the reloadable variables of the source config to a copy of the
+// target config.
+func applyReloadable(target Config, source *Config) *Config {
+	target.Bot.Color = source.Bot.Color
+	target.Bot.LogChannel = source.Bot.LogChannel
+	target.Bot.LogRoutes = source.Bot.LogRoutes
+	target.User = source.User
+
+	return &target
+}
+
+// Getting a description of the reloadable variables changes.
+func changes(old, new *Config) []string {
+	changed := make([]string, 0)
+
+	if old.Bot.Color != new.Bot.Color {
+		changed = append(changed, fmt.Sprintf("bot.color: %#x -> %#x", old.Bot.Color, new.Bot.Color))
+	}
+	if old.Bot.LogChannel != new.Bot.LogChannel {
+		changed = append(changed, fmt.Sprintf("bot.log-channel: %s -> %s", old.Bot.LogChannel, new.Bot.LogChannel))
+	}
+	if !reflect.DeepEqual(old.Bot.LogRoutes, new.Bot.LogRoutes) {
+		changed = append(changed, "bot.log-routes")
+	}
+	if old.User.ReviewRole != new.User.ReviewRole {
+		changed = append(changed, fmt.Sprintf("user.review-role: %s -> %s", old.User.ReviewRole, new.User.ReviewRole))
+	}
+	if old.User.MinAge != new.User.MinAge {
+		changed = append(changed, fmt.Sprintf("user.min-age: %s -> %s", old.User.MinAge, new.User.MinAge))
+	}
+
+	return changed
+}
+
+// Getting the keys of the variables that differ in the configs, the keys are
+// taken from the mapstructure tags or lowercased field names.
+func restartChanges(old, new *Config) []string {
+	return diffKeys("", reflect.ValueOf(*old), reflect.ValueOf(*new))
+}
+
+// Getting the keys of the struct fields that differ.
+func diffKeys(prefix string, old, new reflect.Value) []string {
+	keys := make([]string, 0)
+
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+
+		key, ok := field.Tag.Lookup("mapstructure")
+		if !ok {
+			key = strings.ToLower(field.Name)
+		}
+		key = prefix + key
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			keys = append(keys, diffKeys(key+".", old.Field(i), new.Field(i))...)
+		case !reflect.DeepEqual(old.Field(i).Interface(), new.Field(i).Interface()):
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
 // Parsing specified when starting the config file.
 func parseConfigFile() error {
 	// Get config path variable.
@@ -121,6 +403,7 @@ func setFromEnv(cfg *Config) {
 
 	// Discord bot variables.
 	cfg.Bot.Token = os.Getenv("BOT_TOKEN")
+	cfg.Bot.HTTP.PublicKey = os.Getenv("BOT_PUBLIC_KEY")
 
 	// Mongo database variables.
 	cfg.Database.Mongodb.URI = os.Getenv("MONGO_URI")
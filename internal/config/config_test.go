@@ -18,18 +18,26 @@
 package config_test
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/durudex/discord-promo-bot/internal/config"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 )
 
 // Test initialize config.
 func TestConfig_Init(t *testing.T) {
 	// Environment configurations.
-	type env struct{ configPath, botToken, mongoUri, mongoUsername, mongoPassword string }
+	type env struct {
+		configPath, botToken, botPublicKey, mongoUri, mongoUsername, mongoPassword string
+	}
 
 	// Testing args.
 	type args struct{ env env }
@@ -38,6 +46,7 @@ func TestConfig_Init(t *testing.T) {
 	setEnv := func(env env) {
 		os.Setenv("CONFIG_PATH", env.configPath)
 		os.Setenv("BOT_TOKEN", env.botToken)
+		os.Setenv("BOT_PUBLIC_KEY", env.botPublicKey)
 		os.Setenv("MONGO_URI", env.mongoUri)
 		os.Setenv("MONGO_USERNAME", env.mongoUsername)
 		os.Setenv("MONGO_PASSWORD", env.mongoPassword)
@@ -55,12 +64,32 @@ func TestConfig_Init(t *testing.T) {
 			args: args{env: env{
 				configPath:    "fixtures/main",
 				botToken:      "123",
+				botPublicKey:  "abc",
 				mongoUri:      "mongodb://localhost:27017",
 				mongoUsername: "admin",
 				mongoPassword: "qwerty",
 			}},
 			want: &config.Config{
-				Bot: config.BotConfig{Color: 0xa735ed, LogChannel: "1000376533044695111", Token: "123"},
+				Bot: config.BotConfig{
+					Color:      0xa735ed,
+					LogChannel: "1000376533044695111",
+					Guilds:     []string{"882288646517035028"},
+					Global:     true,
+					Timeout:    time.Second * 10,
+					DeferAfter: time.Second * 2,
+					HTTP:       config.HTTPConfig{Addr: ":8080", PublicKey: "abc"},
+					Cooldowns: map[string]config.CooldownConfig{
+						"use": {User: time.Second * 30, Guild: time.Second * 5},
+					},
+					LogRoutes: []config.LogRouteConfig{
+						{
+							Events:    []string{"balance.adjusted"},
+							Channels:  []string{"1000376533044695112"},
+							MinAmount: 1000,
+						},
+					},
+					Token: "123",
+				},
 				Database: config.DatabaseConfig{
 					Mongodb: config.MongodbConfig{
 						URI:      "mongodb://localhost:27017",
@@ -70,8 +99,25 @@ func TestConfig_Init(t *testing.T) {
 						Database: "durudex",
 					},
 				},
-				User:  config.UserConfig{ReviewRole: "1000363996685271130", MinAge: time.Hour * 1440},
-				Promo: config.PromoConfig{AutoSaveTTL: time.Minute * 5},
+				User: config.UserConfig{ReviewRole: "1000363996685271130", MinAge: time.Hour * 1440},
+				Promo: config.PromoConfig{
+					AutoSaveTTL: time.Minute * 5,
+					EpochSource: config.EpochSourceConfig,
+					Epochs: []config.EpochConfig{
+						{Reward: 1000, UsageLimit: 500},
+						{Reward: 900, UsageLimit: 2000},
+					},
+				},
+				Locale: config.LocaleConfig{Path: "configs/locales", Default: "en-US"},
+				Response: config.ResponseConfig{
+					Ephemeral:    config.EphemeralConfig{Error: true, Info: true, Admin: true},
+					ErrorChannel: "1000376533044695113",
+				},
+				Plugins: []config.PluginConfig{
+					{Name: "basic", Disabled: []string{"github"}},
+					{Name: "user", Disabled: []string{"update-balance"}},
+					{Name: "monitor"},
+				},
 			},
 		},
 	}
@@ -85,13 +131,101 @@ func TestConfig_Init(t *testing.T) {
 			// Initialize config.
 			got, err := config.Init()
 			if (err != nil) != tt.wantErr {
-				t.Errorf("error initialize config: %s", err.Error())
+				t.Fatalf("error initialize config: %s", err.Error())
 			}
 
 			// Check for similarity of a config.
-			if !reflect.DeepEqual(got, tt.want) {
+			if !reflect.DeepEqual(got.Load(), tt.want) {
 				t.Errorf("error config are not similar")
 			}
 		})
 	}
 }
+
+// Test reloading config.
+func TestStore_Reload(t *testing.T) {
+	fixture, err := os.ReadFile("fixtures/main.yml")
+	if err != nil {
+		t.Fatalf("error reading config fixture: %s", err.Error())
+	}
+
+	path := filepath.Join(t.TempDir(), "reload.yml")
+
+	// Writing the config file.
+	write := func(replacer *strings.Replacer) {
+		if err := os.WriteFile(path, []byte(replacer.Replace(string(fixture))), 0o600); err != nil {
+			t.Fatalf("error writing config file: %s", err.Error())
+		}
+	}
+
+	write(strings.NewReplacer())
+
+	os.Setenv("CONFIG_PATH", strings.TrimSuffix(path, ".yml"))
+
+	// Initialize config, the config file is not watched so it is reloaded
+	// only by the test.
+	store, err := config.Init()
+	if err != nil {
+		t.Fatalf("error initialize config: %s", err.Error())
+	}
+
+	// Capturing the reload logs.
+	var logs bytes.Buffer
+
+	logger := log.Logger
+	log.Logger = zerolog.New(&logs)
+	t.Cleanup(func() { log.Logger = logger })
+
+	// Tests structures.
+	tests := []struct {
+		name           string
+		replacer       *strings.Replacer
+		wantLogChannel string
+		wantDatabase   string
+		wantRestart    string
+		wantErr        bool
+	}{
+		{
+			name: "OK",
+			replacer: strings.NewReplacer(
+				"1000376533044695111", "1000376533044695112",
+				`database: "durudex"`, `database: "other"`,
+			),
+			wantLogChannel: "1000376533044695112",
+			wantDatabase:   "durudex",
+			wantRestart:    `"keys":["database.mongodb.database"]`,
+		},
+		{
+			name:           "Invalid",
+			replacer:       strings.NewReplacer(`log-channel: "1000376533044695111"`, `log-channel: ""`),
+			wantLogChannel: "1000376533044695112",
+			wantDatabase:   "durudex",
+			wantErr:        true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write(tt.replacer)
+			logs.Reset()
+
+			// Reloading config.
+			if err := store.Reload(); (err != nil) != tt.wantErr {
+				t.Fatalf("error reloading config: %v", err)
+			}
+
+			cfg := store.Load()
+
+			if cfg.Bot.LogChannel != tt.wantLogChannel {
+				t.Errorf("error log channel are not similar: %s", cfg.Bot.LogChannel)
+			}
+			if cfg.Database.Mongodb.Database != tt.wantDatabase {
+				t.Errorf("error database are not similar: %s", cfg.Database.Mongodb.Database)
+			}
+			if !strings.Contains(logs.String(), tt.wantRestart) {
+				t.Errorf("error restart keys are not logged: %s", logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package domain
+
+import "time"
+
+// Audit event types.
+const (
+	AuditPromoCreated    string = "promo.created"
+	AuditPromoUsed       string = "promo.used"
+	AuditBalanceAdjusted string = "balance.adjusted"
+	AuditEpochAdvanced   string = "epoch.advanced"
+	AuditEpochUpdated    string = "epoch.updated"
+	// Internal error event, it is not recorded and is only sent to the log
+	// channels.
+	AuditError string = "error"
+)
+
+// Checking is the event type can be routed to the log channels.
+func IsLogEvent(eventType string) bool {
+	switch eventType {
+	case AuditPromoCreated, AuditPromoUsed, AuditBalanceAdjusted, AuditEpochAdvanced, AuditEpochUpdated, AuditError:
+		return true
+	default:
+		return false
+	}
+}
+
+// Audit event structure.
+type AuditEvent struct {
+	// Audit event id.
+	Id string `bson:"_id"`
+	// Audit event type.
+	Type string `bson:"type"`
+	// Discord id of the user who performed the action.
+	Actor string `bson:"actor"`
+	// Discord username of the user who performed the action.
+	ActorName string `bson:"actorName,omitempty"`
+	// Avatar url of the user who performed the action.
+	ActorAvatar string `bson:"actorAvatar,omitempty"`
+	// Discord id of the user affected by the action.
+	Target string `bson:"target,omitempty"`
+	// Promo code.
+	Promo string `bson:"promo,omitempty"`
+	// Promo epoch id.
+	Epoch int `bson:"epoch,omitempty"`
+	// Balance change or reward amount.
+	Amount int `bson:"amount,omitempty"`
+	// Promo epoch usage limit.
+	UsageLimit int `bson:"usageLimit,omitempty"`
+	// Action reason.
+	Reason string `bson:"reason,omitempty"`
+	// Audit event created at.
+	CreatedAt time.Time `bson:"createdAt"`
+	// Audit event delivered to the log channel at.
+	DeliveredAt time.Time `bson:"deliveredAt,omitempty"`
+	// Log channels to which the audit event is delivered, they are skipped
+	// when the delivery is retried.
+	DeliveredTo []string `bson:"deliveredTo,omitempty"`
+	// Audit event claimed for delivery until, it is not delivered by others
+	// until the claim expires.
+	ClaimedUntil time.Time `bson:"claimedUntil,omitempty"`
+	// Audit event delivery attempts.
+	Attempts int `bson:"attempts,omitempty"`
+}
@@ -28,15 +28,46 @@ const (
 	CodeNotFound
 	CodeAlreadyExists
 	CodeInvalidArgument
+	// The user is not allowed to perform the action.
+	CodePermissionDenied
+	// The system is not in a state required for the action, for example an
+	// account is too new or a promo code has already been used.
+	CodeFailedPrecondition
+	// A limited resource has been exhausted, for example epoch rewards.
+	CodeResourceExhausted
+	// The action is performed too often.
+	CodeRateLimited
+	// A dependency, for example a database, is temporarily unavailable.
+	CodeUnavailable
 )
 
 // Error structure.
 type Error struct {
 	Code    Code
 	Message string
+	// Message catalog key, if it is specified, the localized message is
+	// shown to the user instead of the message.
+	Key string
+	// Message catalog template data.
+	Data any
+	// Error cause, it is not shown to the user.
+	Err error
+}
+
+// Error message template data with a limit, for example the number of epochs.
+type LimitData struct {
+	Limit int
 }
 
 // Getting error message.
 func (e *Error) Error() string {
+	// Checking is message specified.
+	if e.Message == "" {
+		return fmt.Sprintf("%d: %s", e.Code, e.Key)
+	}
+
 	return fmt.Sprintf("%d: %s", e.Code, e.Message)
 }
+
+// Getting error cause.
+func (e *Error) Unwrap() error { return e.Err }
@@ -17,19 +17,11 @@
 
 package domain
 
-import "time"
-
-// Max promo epoch.
-const MaxMonitorEpoch int = 5
-
-// Promo monitor epochs.
-var Epochs map[int]*Monitor = map[int]*Monitor{
-	1: {Id: 1, Reward: 1000, UsageLimit: 500},
-	2: {Id: 2, Reward: 900, UsageLimit: 2000},
-	3: {Id: 3, Reward: 800, UsageLimit: 2500},
-	4: {Id: 4, Reward: 700, UsageLimit: 10000},
-	5: {Id: 5, Reward: 600, UsageLimit: 10000},
-}
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Promo monitor structure.
 type Monitor struct {
@@ -44,3 +36,24 @@ type Monitor struct {
 	// Updated at promo monitor.
 	UpdatedAt time.Time `bson:"updatedAt,omitempty"`
 }
+
+// Validating promo epochs table. Epochs are ordered by id, which starts from
+// one, and each epoch must have a positive reward and usage limit.
+func ValidateEpochs(epochs []Monitor) error {
+	if len(epochs) == 0 {
+		return errors.New("epochs are required")
+	}
+
+	for i, epoch := range epochs {
+		switch {
+		case epoch.Id != i+1:
+			return fmt.Errorf("epoch %d must have id %d", epoch.Id, i+1)
+		case epoch.Reward <= 0:
+			return fmt.Errorf("epoch %d reward must be positive", epoch.Id)
+		case epoch.UsageLimit <= 0:
+			return fmt.Errorf("epoch %d usage limit must be positive", epoch.Id)
+		}
+	}
+
+	return nil
+}
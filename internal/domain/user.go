@@ -40,7 +40,7 @@ type User struct {
 func (u User) Validate() error {
 	switch {
 	case !RxPromo.MatchString(u.Promo):
-		return &Error{Code: CodeInvalidArgument, Message: "The promo code is invalid."}
+		return &Error{Code: CodeInvalidArgument, Key: "errors.invalid-promo"}
 	default:
 		return nil
 	}
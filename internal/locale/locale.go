@@ -0,0 +1,438 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package locale
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// Message catalog file extension.
+const catalogExt string = ".yml"
+
+// Message catalog structure.
+type Catalog struct {
+	// Fallback locale, it is used when a message is not found in the locale.
+	fallback discordgo.Locale
+	// Messages by locale and key.
+	messages map[discordgo.Locale]map[string]string
+	// Message templates by locale, each message is a template named after
+	// the message key.
+	templates map[discordgo.Locale]*template.Template
+}
+
+// Loading message catalogs from the directory. Each catalog file is named
+// after the discord locale, for example "en-US.yml" or "uk.yml", and each
+// message is a Go template.
+func Load(dir string, fallback discordgo.Locale) (*Catalog, error) {
+	log.Debug().Msgf("Loading message catalogs: %s", dir)
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		fallback:  fallback,
+		messages:  make(map[discordgo.Locale]map[string]string),
+		templates: make(map[discordgo.Locale]*template.Template),
+	}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != catalogExt {
+			continue
+		}
+
+		locale := discordgo.Locale(strings.TrimSuffix(file.Name(), catalogExt))
+
+		// Checking is locale supported by discord.
+		if _, ok := discordgo.Locales[locale]; !ok {
+			return nil, fmt.Errorf("unknown catalog locale %s", locale)
+		}
+
+		messages, err := loadMessages(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		templates, err := parseMessages(locale, messages)
+		if err != nil {
+			return nil, err
+		}
+
+		c.messages[locale] = messages
+		c.templates[locale] = templates
+	}
+
+	// Checking is fallback catalog exists.
+	if _, ok := c.messages[fallback]; !ok {
+		return nil, fmt.Errorf("fallback catalog %s not found", fallback)
+	}
+
+	return c, nil
+}
+
+// Loading messages from the catalog file.
+func loadMessages(path string) (map[string]string, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	// Read catalog file.
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]string)
+
+	for _, key := range v.AllKeys() {
+		messages[key] = v.GetString(key)
+	}
+
+	return messages, nil
+}
+
+// Parsing the catalog messages templates.
+func parseMessages(locale discordgo.Locale, messages map[string]string) (*template.Template, error) {
+	templates := template.New(string(locale)).Option("missingkey=error")
+
+	for key, message := range messages {
+		if _, err := templates.New(key).Parse(message); err != nil {
+			return nil, fmt.Errorf("error parsing %s catalog message: %w", locale, err)
+		}
+	}
+
+	return templates, nil
+}
+
+// Getting a message by key for the locale, the message template is executed
+// with the data. If the message is not found, the fallback locale is used, and
+// if it is not found there either, the key is returned.
+func (c *Catalog) Message(locale discordgo.Locale, key string, data any) string {
+	t := c.lookup(locale, key)
+	if t == nil {
+		if t = c.lookup(c.fallback, key); t == nil {
+			log.Warn().Str("key", key).Msg("message not found in catalog")
+			return key
+		}
+	}
+
+	var message strings.Builder
+
+	// Executing the message template.
+	if err := t.Execute(&message, data); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("failed to execute message template")
+		return key
+	}
+
+	return message.String()
+}
+
+// Getting a message by key for the fallback locale.
+func (c *Catalog) Default(key string, data any) string {
+	return c.Message(c.fallback, key, data)
+}
+
+// Validating the catalog messages by the templates data. Each message must
+// exist in the fallback catalog and must be executed with its data type in
+// every catalog.
+func (c *Catalog) Validate(templates map[string]any) error {
+	keys := make([]string, 0, len(templates))
+	for key := range templates {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		// Checking is message exists in the fallback catalog.
+		if c.lookup(c.fallback, key) == nil {
+			return fmt.Errorf("message %s not found in fallback catalog %s", key, c.fallback)
+		}
+
+		for locale := range c.templates {
+			t := c.lookup(locale, key)
+			if t == nil {
+				continue
+			}
+
+			// Executing the message template with the data type.
+			if err := t.Execute(io.Discard, templates[key]); err != nil {
+				return fmt.Errorf("error validating %s catalog message: %w", locale, err)
+			}
+
+			// Checking fields in all template branches, branches which are
+			// not executed with the zero data are checked too.
+			if err := checkFields(t.Tree.Root, dataType(templates[key]), dataType(templates[key])); err != nil {
+				return fmt.Errorf("error validating %s catalog message %s: %w", locale, key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Getting the type of the template data, nil data has no fields.
+func dataType(data any) reflect.Type {
+	if data == nil {
+		return reflect.TypeOf(struct{}{})
+	}
+
+	return reflect.TypeOf(data)
+}
+
+// Checking the template node fields by the dot and root data types. A nil type
+// is unknown, for example the type of a declared variable, and is not checked.
+func checkFields(node parse.Node, dot, root reflect.Type) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		for _, node := range n.Nodes {
+			if err := checkFields(node, dot, root); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		_, err := pipeType(n.Pipe, dot, root)
+		return err
+	case *parse.IfNode:
+		return checkBranches(&n.BranchNode, dot, dot, root)
+	case *parse.WithNode:
+		t, err := pipeType(n.Pipe, dot, root)
+		if err != nil {
+			return err
+		}
+
+		return checkBranches(&n.BranchNode, t, dot, root)
+	case *parse.RangeNode:
+		t, err := pipeType(n.Pipe, dot, root)
+		if err != nil {
+			return err
+		}
+
+		return checkBranches(&n.BranchNode, elemType(t), dot, root)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			_, err := pipeType(n.Pipe, dot, root)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Checking the branch node fields, the list is checked with the branch dot
+// type and the else list with the outer dot type.
+func checkBranches(n *parse.BranchNode, branch, dot, root reflect.Type) error {
+	if err := checkFields(n.List, branch, root); err != nil {
+		return err
+	}
+
+	if n.ElseList != nil {
+		return checkFields(n.ElseList, dot, root)
+	}
+
+	return nil
+}
+
+// Checking the pipeline fields and getting the pipeline result type.
+func pipeType(pipe *parse.PipeNode, dot, root reflect.Type) (reflect.Type, error) {
+	var result reflect.Type
+
+	for _, cmd := range pipe.Cmds {
+		result = nil
+
+		for _, arg := range cmd.Args {
+			t, err := argType(arg, dot, root)
+			if err != nil {
+				return nil, err
+			}
+
+			result = t
+		}
+
+		// Function calls result type is unknown.
+		if len(cmd.Args) != 1 {
+			result = nil
+		}
+	}
+
+	return result, nil
+}
+
+// Checking the command argument fields and getting the argument type.
+func argType(node parse.Node, dot, root reflect.Type) (reflect.Type, error) {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot, nil
+	case *parse.FieldNode:
+		return fieldType(dot, n.Ident)
+	case *parse.VariableNode:
+		// Checking is variable the root data.
+		if n.Ident[0] == "$" {
+			return fieldType(root, n.Ident[1:])
+		}
+	case *parse.PipeNode:
+		return pipeType(n, dot, root)
+	case *parse.ChainNode:
+		t, err := argType(n.Node, dot, root)
+		if err != nil {
+			return nil, err
+		}
+
+		return fieldType(t, n.Field)
+	}
+
+	return nil, nil
+}
+
+// Getting the type of the fields chain.
+func fieldType(t reflect.Type, names []string) (reflect.Type, error) {
+	for _, name := range names {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		// Checking is type unknown.
+		if t == nil || t.Kind() == reflect.Interface {
+			return nil, nil
+		}
+
+		// Checking is field a method.
+		if method, ok := reflect.PointerTo(t).MethodByName(name); ok && method.Type.NumOut() != 0 {
+			t = method.Type.Out(0)
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := t.FieldByName(name)
+			if !ok || !field.IsExported() {
+				return nil, fmt.Errorf("can't evaluate field %s in type %s", name, t)
+			}
+
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("can't evaluate field %s in type %s", name, t)
+		}
+	}
+
+	return t, nil
+}
+
+// Getting the element type of the range pipeline type.
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+		return t.Elem()
+	}
+
+	return nil
+}
+
+// Getting the message template by key for the locale.
+func (c *Catalog) lookup(locale discordgo.Locale, key string) *template.Template {
+	templates, ok := c.templates[locale]
+	if !ok {
+		return nil
+	}
+
+	return templates.Lookup(key)
+}
+
+// Localizing a discord application command definition. Localizations are
+// taken from the "commands.<command>" keys of each catalog, where the command
+// name is in lower case and spaces are replaced with dashes:
+//
+//	commands:
+//	  update-balance:
+//	    description: "..."
+//	    options:
+//	      user:
+//	        description: "..."
+func (c *Catalog) LocalizeCommand(cmd *discordgo.ApplicationCommand) {
+	key := "commands." + strings.ReplaceAll(strings.ToLower(cmd.Name), " ", "-")
+
+	if names := c.localizations(key + ".name"); len(names) != 0 {
+		cmd.NameLocalizations = &names
+	}
+	if descriptions := c.localizations(key + ".description"); len(descriptions) != 0 {
+		cmd.DescriptionLocalizations = &descriptions
+	}
+
+	cmd.Options = c.localizeOptions(key, cmd.Options)
+}
+
+// Localizing a copy of the discord application command options, the options
+// can be shared with other command definitions.
+func (c *Catalog) localizeOptions(key string, options []*discordgo.ApplicationCommandOption) []*discordgo.ApplicationCommandOption {
+	if len(options) == 0 {
+		return options
+	}
+
+	localized := make([]*discordgo.ApplicationCommandOption, len(options))
+
+	for i, option := range options {
+		o := *option
+		optionKey := key + ".options." + o.Name
+
+		if names := c.localizations(optionKey + ".name"); len(names) != 0 {
+			o.NameLocalizations = names
+		}
+		if descriptions := c.localizations(optionKey + ".description"); len(descriptions) != 0 {
+			o.DescriptionLocalizations = descriptions
+		}
+
+		o.Options = c.localizeOptions(optionKey, o.Options)
+		localized[i] = &o
+	}
+
+	return localized
+}
+
+// Getting the message in every locale that has it.
+func (c *Catalog) localizations(key string) map[discordgo.Locale]string {
+	localizations := make(map[discordgo.Locale]string)
+
+	for locale, messages := range c.messages {
+		if message, ok := messages[key]; ok {
+			localizations[locale] = message
+		}
+	}
+
+	return localizations
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package locale_test
+
+import (
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/internal/locale"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test getting a catalog message.
+func TestCatalog_Message(t *testing.T) {
+	// Loading message catalogs.
+	c, err := locale.Load("fixtures", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	// Testing args.
+	type args struct {
+		locale discordgo.Locale
+		key    string
+		data   any
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "OK",
+			args: args{locale: discordgo.Ukrainian, key: "register.success"},
+			want: "Ви успішно зареєструвалися!",
+		},
+		{
+			name: "Fallback",
+			args: args{locale: discordgo.Ukrainian, key: "use.success", data: struct{ Promo string }{"durudex"}},
+			want: "You used promo code `durudex`",
+		},
+		{
+			name: "Unknown Locale",
+			args: args{locale: discordgo.German, key: "register.success"},
+			want: "You have successfully registered!",
+		},
+		{
+			name: "Unknown Key",
+			args: args{locale: discordgo.EnglishUS, key: "unknown"},
+			want: "unknown",
+		},
+		{
+			name: "Invalid Data",
+			args: args{locale: discordgo.EnglishUS, key: "use.success"},
+			want: "use.success",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Getting a catalog message.
+			got := c.Message(tt.args.locale, tt.args.key, tt.args.data)
+			if got != tt.want {
+				t.Errorf("error message are not similar: %s", got)
+			}
+		})
+	}
+}
+
+// Test validating catalog messages.
+func TestCatalog_Validate(t *testing.T) {
+	// Loading message catalogs.
+	c, err := locale.Load("fixtures", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name      string
+		templates map[string]any
+		wantErr   bool
+	}{
+		{
+			name: "OK",
+			templates: map[string]any{
+				"register.success": nil,
+				"use.success":      struct{ Promo string }{},
+			},
+		},
+		{
+			name:      "Not Found",
+			templates: map[string]any{"unknown": nil},
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid Data",
+			templates: map[string]any{"use.success": struct{ Code string }{}},
+			wantErr:   true,
+		},
+		{
+			name: "Branches",
+			templates: map[string]any{
+				"use.reward": struct {
+					Reward int
+					Promo  string
+				}{},
+				"use.codes": struct{ Codes []struct{ Name string } }{},
+			},
+		},
+		{
+			name:      "Invalid If Branch Data",
+			templates: map[string]any{"use.reward": struct{ Reward int }{}},
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid Range Branch Data",
+			templates: map[string]any{"use.codes": struct{ Codes []struct{ Code string } }{}},
+			wantErr:   true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Validating catalog messages.
+			if err := c.Validate(tt.templates); (err != nil) != tt.wantErr {
+				t.Errorf("error validating catalog messages: %v", err)
+			}
+		})
+	}
+}
+
+// Test localizing a discord application command.
+func TestCatalog_LocalizeCommand(t *testing.T) {
+	// Loading message catalogs.
+	c, err := locale.Load("fixtures", discordgo.EnglishUS)
+	if err != nil {
+		t.Fatalf("error loading message catalogs: %s", err.Error())
+	}
+
+	options := []*discordgo.ApplicationCommandOption{{Name: "promo", Description: "Promo code."}}
+
+	cmd := &discordgo.ApplicationCommand{
+		Name:        "use",
+		Description: "The command use a user promo code.",
+		Options:     options,
+	}
+
+	// Localizing the application command.
+	c.LocalizeCommand(cmd)
+
+	if cmd.NameLocalizations != nil {
+		t.Errorf("error unexpected name localizations: %v", *cmd.NameLocalizations)
+	}
+	if cmd.DescriptionLocalizations == nil || (*cmd.DescriptionLocalizations)[discordgo.Ukrainian] == "" {
+		t.Errorf("error command description is not localized")
+	}
+	if cmd.Options[0].DescriptionLocalizations[discordgo.Ukrainian] != "Промокод." {
+		t.Errorf("error option description is not localized")
+	}
+	if options[0].DescriptionLocalizations != nil {
+		t.Errorf("error shared option is localized")
+	}
+
+	profile := &discordgo.ApplicationCommand{Type: discordgo.UserApplicationCommand, Name: "Promo profile"}
+
+	// Localizing the application command.
+	c.LocalizeCommand(profile)
+
+	if profile.NameLocalizations == nil || (*profile.NameLocalizations)[discordgo.Ukrainian] != "Промо профіль" {
+		t.Errorf("error command name is not localized")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Mongodb database collection.
+const auditCollection string = "audit"
+
+// Audit repository interface.
+type Audit interface {
+	// Creating a new audit event, it returns the audit event id.
+	Create(ctx context.Context, event domain.AuditEvent) (string, error)
+	// Claiming the oldest undelivered audit event until the time, events that
+	// are claimed by others or have the max delivery attempts are skipped.
+	Claim(ctx context.Context, now, until time.Time, maxAttempts int) (domain.AuditEvent, error)
+	// Adding the log channels to which the audit event is delivered.
+	AddDeliveredTo(ctx context.Context, id string, channels []string) error
+	// Marking the audit event as delivered.
+	MarkDelivered(ctx context.Context, id string, deliveredAt time.Time) error
+}
+
+// Audit repository structure.
+type AuditRepository struct{ coll *mongo.Collection }
+
+// Creating a new audit repository.
+func NewAuditRepository(db *mongo.Database) *AuditRepository {
+	return &AuditRepository{coll: db.Collection(auditCollection)}
+}
+
+// Creating a new audit event, it returns the audit event id.
+func (r *AuditRepository) Create(ctx context.Context, event domain.AuditEvent) (string, error) {
+	event.Id = primitive.NewObjectID().Hex()
+
+	if _, err := r.coll.InsertOne(ctx, event); err != nil {
+		return "", mongoError(err)
+	}
+
+	return event.Id, nil
+}
+
+// Claiming the oldest undelivered audit event until the time, events that are
+// claimed by others or have the max delivery attempts are skipped. The claim
+// increments the event delivery attempts.
+func (r *AuditRepository) Claim(ctx context.Context, now, until time.Time, maxAttempts int) (domain.AuditEvent, error) {
+	var event domain.AuditEvent
+
+	if err := r.coll.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"deliveredAt": bson.M{"$exists": false},
+			"attempts":    bson.M{"$not": bson.M{"$gte": maxAttempts}},
+			"$or": bson.A{
+				bson.M{"claimedUntil": bson.M{"$exists": false}},
+				bson.M{"claimedUntil": bson.M{"$lte": now}},
+			},
+		},
+		bson.M{"$set": bson.M{"claimedUntil": until}, "$inc": bson.M{"attempts": 1}},
+		options.FindOneAndUpdate().SetSort(bson.M{"createdAt": 1}).SetReturnDocument(options.After),
+	).Decode(&event); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.AuditEvent{}, &domain.Error{Code: domain.CodeNotFound}
+		}
+
+		return domain.AuditEvent{}, mongoError(err)
+	}
+
+	return event, nil
+}
+
+// Adding the log channels to which the audit event is delivered.
+func (r *AuditRepository) AddDeliveredTo(ctx context.Context, id string, channels []string) error {
+	_, err := r.coll.UpdateByID(ctx, id, bson.M{"$addToSet": bson.M{"deliveredTo": bson.M{"$each": channels}}})
+
+	return mongoError(err)
+}
+
+// Marking the audit event as delivered.
+func (r *AuditRepository) MarkDelivered(ctx context.Context, id string, deliveredAt time.Time) error {
+	_, err := r.coll.UpdateByID(ctx, id, bson.M{"$set": bson.M{"deliveredAt": deliveredAt}})
+
+	return mongoError(err)
+}
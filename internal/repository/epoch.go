@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package repository
+
+import (
+	"context"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Mongodb database collection.
+const epochCollection string = "epoch"
+
+// Epoch repository interface.
+type Epoch interface {
+	// Getting all promo epochs ordered by id.
+	GetAll(ctx context.Context) ([]domain.Monitor, error)
+}
+
+// Epoch repository structure.
+type EpochRepository struct{ coll *mongo.Collection }
+
+// Creating a new epoch repository.
+func NewEpochRepository(db *mongo.Database) *EpochRepository {
+	return &EpochRepository{coll: db.Collection(epochCollection)}
+}
+
+// Getting all promo epochs ordered by id.
+func (r *EpochRepository) GetAll(ctx context.Context) ([]domain.Monitor, error) {
+	cursor, err := r.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, mongoError(err)
+	}
+
+	var epochs []domain.Monitor
+
+	if err := cursor.All(ctx, &epochs); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return epochs, nil
+}
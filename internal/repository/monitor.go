@@ -68,10 +68,10 @@ func (r *MonitorRepository) Get(ctx context.Context, id int, last bool) (domain.
 		opts,
 	).Decode(&monitor); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return domain.Monitor{}, &domain.Error{Code: domain.CodeNotFound, Message: "Epoch not found."}
+			return domain.Monitor{}, &domain.Error{Code: domain.CodeNotFound, Key: "errors.epoch-not-found"}
 		}
 
-		return domain.Monitor{}, err
+		return domain.Monitor{}, mongoError(err)
 	}
 
 	return monitor, nil
@@ -101,5 +101,5 @@ func (r *MonitorRepository) Update(ctx context.Context, monitor domain.Monitor)
 		options.Update().SetUpsert(true),
 	)
 
-	return err
+	return mongoError(err)
 }
@@ -17,12 +17,21 @@
 
 package repository
 
-import "go.mongodb.org/mongo-driver/mongo"
+import (
+	"errors"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
 
 // Repository structure.
 type Repository struct {
 	User    User
 	Monitor Monitor
+	Audit   Audit
+	Epoch   Epoch
 }
 
 // Creating a new repository.
@@ -30,5 +39,20 @@ func NewRepository(db *mongo.Database) *Repository {
 	return &Repository{
 		User:    NewUserRepository(db),
 		Monitor: NewMonitorRepository(db),
+		Audit:   NewAuditRepository(db),
+		Epoch:   NewEpochRepository(db),
 	}
 }
+
+// Converting a mongodb error to the domain error. Connection errors and
+// timeouts are returned as unavailable errors, other errors are returned as is.
+func mongoError(err error) error {
+	var selection topology.ServerSelectionError
+
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) || errors.As(err, &selection) ||
+		errors.Is(err, mongo.ErrClientDisconnected) {
+		return &domain.Error{Code: domain.CodeUnavailable, Key: "errors.unavailable", Err: err}
+	}
+
+	return err
+}
@@ -55,10 +55,10 @@ func NewUserRepository(db *mongo.Database) *UserRepository {
 func (r *UserRepository) Create(ctx context.Context, user domain.User) error {
 	_, err := r.coll.InsertOne(ctx, user)
 	if mongo.IsDuplicateKeyError(err) {
-		return &domain.Error{Code: domain.CodeAlreadyExists, Message: "You are registered."}
+		return &domain.Error{Code: domain.CodeAlreadyExists, Key: "errors.registered"}
 	}
 
-	return err
+	return mongoError(err)
 }
 
 // Getting a user.
@@ -67,10 +67,10 @@ func (r *UserRepository) Get(ctx context.Context, id string) (domain.User, error
 
 	if err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&user); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return domain.User{}, &domain.Error{Code: domain.CodeNotFound, Message: "User not found."}
+			return domain.User{}, &domain.Error{Code: domain.CodeNotFound, Key: "errors.user-not-found"}
 		}
 
-		return domain.User{}, err
+		return domain.User{}, mongoError(err)
 	}
 
 	return user, nil
@@ -84,12 +84,12 @@ func (r *UserRepository) UpdatePromo(ctx context.Context, id, promo string) erro
 		bson.M{"$set": bson.M{"promo": promo}},
 	).Err(); err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return &domain.Error{Code: domain.CodeAlreadyExists, Message: "The promo already exists."}
+			return &domain.Error{Code: domain.CodeAlreadyExists, Key: "errors.promo-exists"}
 		} else if err == mongo.ErrNoDocuments {
-			return &domain.Error{Code: domain.CodeNotFound, Message: "User does not exist or has already created a promo code."}
+			return &domain.Error{Code: domain.CodeFailedPrecondition, Key: "errors.promo-created"}
 		}
 
-		return err
+		return mongoError(err)
 	}
 
 	return nil
@@ -104,7 +104,7 @@ func (r *UserRepository) UsePromo(ctx context.Context, id, promo string, reward
 		err := r.coll.FindOne(sessCtx, bson.M{"promo": promo}).Decode(&user)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
-				return nil, &domain.Error{Code: domain.CodeNotFound, Message: "Promo code not found."}
+				return nil, &domain.Error{Code: domain.CodeNotFound, Key: "errors.promo-not-found"}
 			}
 
 			return nil, err
@@ -112,7 +112,7 @@ func (r *UserRepository) UsePromo(ctx context.Context, id, promo string, reward
 
 		// Check if is author.
 		if user.Id == id {
-			return nil, &domain.Error{Code: domain.CodeInvalidArgument, Message: "You can't use your own promo code."}
+			return nil, &domain.Error{Code: domain.CodeInvalidArgument, Key: "errors.own-promo"}
 		}
 
 		// Update a user used promo and increment balance.
@@ -122,7 +122,7 @@ func (r *UserRepository) UsePromo(ctx context.Context, id, promo string, reward
 			bson.M{"$set": bson.M{"used": promo}, "$inc": bson.M{"balance": reward}},
 		).Err(); err != nil {
 			if err == mongo.ErrNoDocuments {
-				return nil, &domain.Error{Code: domain.CodeNotFound, Message: "User does not exist or has already used the promo code."}
+				return nil, &domain.Error{Code: domain.CodeFailedPrecondition, Key: "errors.promo-used"}
 			}
 
 			return nil, err
@@ -140,14 +140,14 @@ func (r *UserRepository) UsePromo(ctx context.Context, id, promo string, reward
 	// Creating a new mongodb session.
 	session, err := r.coll.Database().Client().StartSession()
 	if err != nil {
-		return err
+		return mongoError(err)
 	}
 	defer session.EndSession(ctx)
 
 	// Executing the callback.
 	_, err = session.WithTransaction(ctx, callback)
 
-	return err
+	return mongoError(err)
 }
 
 // Updating a user balance.
@@ -155,10 +155,10 @@ func (r *UserRepository) UpdateBalance(ctx context.Context, discordId string, am
 	// Update a user used balance.
 	if err := r.coll.FindOneAndUpdate(ctx, bson.M{"_id": discordId}, bson.M{"$inc": bson.M{"balance": amount}}).Err(); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return &domain.Error{Code: domain.CodeNotFound, Message: "User does not exist."}
+			return &domain.Error{Code: domain.CodeNotFound, Key: "errors.user-not-exist"}
 		}
 
-		return err
+		return mongoError(err)
 	}
 
 	return nil
@@ -0,0 +1,188 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+	"github.com/durudex/discord-promo-bot/internal/repository"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Queue size of the audit events that are not saved, they are delivered
+	// without saving.
+	auditQueueSize int = 100
+	// Interval of the pending audit events delivery.
+	auditDeliveryInterval time.Duration = time.Minute
+	// Audit event delivery claim duration, if the event is not delivered
+	// within it, the event is claimed again by the next delivery.
+	auditClaimDuration time.Duration = time.Minute
+	// Max audit event delivery attempts.
+	auditMaxAttempts int = 5
+	// Timeout of saving the audit event.
+	auditRecordTimeout time.Duration = time.Second * 5
+)
+
+// Audit event delivery function, for example sending the event to the log
+// channels. It returns the channels to which the event is delivered, even if
+// the delivery fails, channels from the event delivered to list are skipped.
+type AuditDeliverer func(ctx context.Context, event domain.AuditEvent) ([]string, error)
+
+// Audit service interface.
+type Audit interface {
+	// Recording an audit event. The event is saved to the database and then
+	// delivered asynchronously, if saving fails, the event is delivered
+	// without saving.
+	Record(event domain.AuditEvent) error
+	// Delivering recorded audit events until the context is canceled.
+	Run(ctx context.Context, deliver AuditDeliverer)
+}
+
+// Audit service structure.
+type AuditService struct {
+	// Audit repository.
+	repos repository.Audit
+	// Queue of the audit events that are not saved.
+	queue chan domain.AuditEvent
+	// Notification about recorded audit events.
+	recorded chan struct{}
+	// Audit event delivery claim duration.
+	claimDuration time.Duration
+}
+
+// Creating a new audit service.
+func NewAuditService(repos repository.Audit) *AuditService {
+	return &AuditService{
+		repos:         repos,
+		queue:         make(chan domain.AuditEvent, auditQueueSize),
+		recorded:      make(chan struct{}, 1),
+		claimDuration: auditClaimDuration,
+	}
+}
+
+// Recording an audit event. The event is saved to the database and then
+// delivered asynchronously, if saving fails, the event is delivered without
+// saving. The event is saved with its own timeout, so it is recorded even if
+// the interaction context is done.
+func (s *AuditService) Record(event domain.AuditEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), auditRecordTimeout)
+	defer cancel()
+
+	// Saving the audit event.
+	if _, err := s.repos.Create(ctx, event); err != nil {
+		// Queueing the audit event delivery without saving.
+		select {
+		case s.queue <- event:
+		default:
+			log.Error().Str("type", event.Type).Msg("audit queue is full, event is dropped")
+		}
+
+		return err
+	}
+
+	// Notifying about the recorded audit event.
+	select {
+	case s.recorded <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Delivering recorded audit events until the context is canceled. Events are
+// delivered from the database after the recording and periodically, so events
+// that are not delivered are retried.
+func (s *AuditService) Run(ctx context.Context, deliver AuditDeliverer) {
+	ticker := time.NewTicker(auditDeliveryInterval)
+	defer ticker.Stop()
+
+	s.deliverPending(ctx, deliver)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-s.queue:
+			// Delivering the audit event that is not saved.
+			if _, err := deliver(ctx, event); err != nil {
+				log.Error().Err(err).Str("type", event.Type).Msg("failed to deliver audit event")
+			}
+		case <-s.recorded:
+			s.deliverPending(ctx, deliver)
+		case <-ticker.C:
+			s.deliverPending(ctx, deliver)
+		}
+	}
+}
+
+// Delivering the pending audit events. Each event is claimed before the
+// delivery, so it is not delivered twice. The delivery is stopped on the first
+// failure, the remaining events are delivered on the next run.
+func (s *AuditService) deliverPending(ctx context.Context, deliver AuditDeliverer) {
+	for ctx.Err() == nil {
+		now := time.Now()
+
+		// Claiming the pending audit event.
+		event, err := s.repos.Claim(ctx, now, now.Add(s.claimDuration), auditMaxAttempts)
+		if err != nil {
+			var e *domain.Error
+
+			// Checking is there are no pending audit events.
+			if !errors.As(err, &e) || e.Code != domain.CodeNotFound {
+				log.Error().Err(err).Msg("failed to claim audit event")
+			}
+
+			return
+		}
+
+		channels, err := deliver(ctx, event)
+
+		// Saving the channels to which the audit event is delivered.
+		if len(channels) != 0 {
+			if err := s.repos.AddDeliveredTo(ctx, event.Id, channels); err != nil {
+				log.Error().Err(err).Str("event", event.Id).Msg("failed to save audit event delivered channels")
+				return
+			}
+		}
+
+		if err != nil {
+			if event.Attempts >= auditMaxAttempts {
+				log.Error().Err(err).Str("event", event.Id).Msg("failed to deliver audit event")
+			} else {
+				log.Warn().Err(err).Str("event", event.Id).Int("attempt", event.Attempts).Msg("failed to deliver audit event, retrying")
+			}
+
+			return
+		}
+
+		// Marking the audit event as delivered.
+		if err := s.repos.MarkDelivered(ctx, event.Id, time.Now()); err != nil {
+			log.Error().Err(err).Str("event", event.Id).Msg("failed to mark audit event as delivered")
+			return
+		}
+	}
+}
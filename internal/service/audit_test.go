@@ -0,0 +1,271 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+)
+
+// In-memory audit repository.
+type auditRepository struct {
+	events []domain.AuditEvent
+	err    error
+}
+
+// Creating a new audit event, it returns the audit event id.
+func (r *auditRepository) Create(ctx context.Context, event domain.AuditEvent) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	event.Id = string(rune('a' + len(r.events)))
+	r.events = append(r.events, event)
+
+	return event.Id, nil
+}
+
+// Claiming the oldest undelivered audit event until the time.
+func (r *auditRepository) Claim(ctx context.Context, now, until time.Time, maxAttempts int) (domain.AuditEvent, error) {
+	for i, event := range r.events {
+		if !event.DeliveredAt.IsZero() || event.Attempts >= maxAttempts || event.ClaimedUntil.After(now) {
+			continue
+		}
+
+		r.events[i].ClaimedUntil = until
+		r.events[i].Attempts++
+
+		return r.events[i], nil
+	}
+
+	return domain.AuditEvent{}, &domain.Error{Code: domain.CodeNotFound}
+}
+
+// Adding the log channels to which the audit event is delivered.
+func (r *auditRepository) AddDeliveredTo(ctx context.Context, id string, channels []string) error {
+	for i := range r.events {
+		if r.events[i].Id == id {
+			r.events[i].DeliveredTo = append(r.events[i].DeliveredTo, channels...)
+		}
+	}
+
+	return nil
+}
+
+// Marking the audit event as delivered.
+func (r *auditRepository) MarkDelivered(ctx context.Context, id string, deliveredAt time.Time) error {
+	for i := range r.events {
+		if r.events[i].Id == id {
+			r.events[i].DeliveredAt = deliveredAt
+		}
+	}
+
+	return nil
+}
+
+// Test recording an audit event.
+func TestAuditService_Record(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name       string
+		repoErr    error
+		wantSaved  int
+		wantQueued int
+		wantNotify bool
+	}{
+		{name: "OK", wantSaved: 1, wantNotify: true},
+		{name: "Not Saved", repoErr: errors.New("database is unavailable"), wantQueued: 1},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := &auditRepository{err: tt.repoErr}
+			s := NewAuditService(repos)
+
+			// Recording the audit event, it is delivered without saving if
+			// saving fails.
+			if err := s.Record(domain.AuditEvent{Type: domain.AuditPromoUsed}); !errors.Is(err, tt.repoErr) {
+				t.Fatalf("error recording audit event: %v", err)
+			}
+
+			if len(repos.events) != tt.wantSaved || (tt.wantSaved != 0 && repos.events[0].CreatedAt.IsZero()) {
+				t.Errorf("error saved audit events are not similar: %v", repos.events)
+			}
+			if len(s.queue) != tt.wantQueued {
+				t.Errorf("error queued audit events are not similar: %d", len(s.queue))
+			}
+			if notified := len(s.recorded) == 1; notified != tt.wantNotify {
+				t.Errorf("error notified are not similar: %t", notified)
+			}
+		})
+	}
+}
+
+// Test delivering the pending audit events.
+func TestAuditService_DeliverPending(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		events        []domain.AuditEvent
+		channels      []string
+		failures      int
+		passes        int
+		wantAttempts  int
+		wantDelivered int
+		wantSent      int
+	}{
+		{
+			name:          "OK",
+			events:        []domain.AuditEvent{{Id: "a"}, {Id: "b"}},
+			passes:        1,
+			wantAttempts:  2,
+			wantDelivered: 2,
+			wantSent:      2,
+		},
+		{
+			name:   "Claimed",
+			events: []domain.AuditEvent{{Id: "a", ClaimedUntil: time.Now().Add(time.Minute)}},
+			passes: 1,
+		},
+		{
+			name:         "Failure Stops Delivery",
+			events:       []domain.AuditEvent{{Id: "a"}, {Id: "b"}},
+			failures:     1,
+			passes:       1,
+			wantAttempts: 1,
+		},
+		{
+			name:          "Retry",
+			events:        []domain.AuditEvent{{Id: "a"}},
+			failures:      2,
+			passes:        3,
+			wantAttempts:  3,
+			wantDelivered: 1,
+			wantSent:      1,
+		},
+		{
+			name:          "Partial",
+			events:        []domain.AuditEvent{{Id: "a"}},
+			channels:      []string{"log", "moderators"},
+			failures:      1,
+			passes:        2,
+			wantAttempts:  2,
+			wantDelivered: 1,
+			wantSent:      2,
+		},
+		{
+			name:         "Max Attempts",
+			events:       []domain.AuditEvent{{Id: "a"}},
+			failures:     auditMaxAttempts + 1,
+			passes:       auditMaxAttempts + 1,
+			wantAttempts: auditMaxAttempts,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := &auditRepository{events: tt.events}
+
+			s := NewAuditService(repos)
+			s.claimDuration = 0
+
+			channels := tt.channels
+			if channels == nil {
+				channels = []string{"log"}
+			}
+
+			var (
+				attempts int
+				sent     = make(map[string]int)
+			)
+
+			// Delivering the audit event to the channels, the last channel
+			// fails while there are failures.
+			deliver := func(ctx context.Context, event domain.AuditEvent) ([]string, error) {
+				attempts++
+				delivered := make([]string, 0, len(channels))
+
+				for i, channel := range channels {
+					if contains(event.DeliveredTo, channel) {
+						continue
+					}
+
+					if i == len(channels)-1 && attempts <= tt.failures {
+						return delivered, errors.New("discord is unavailable")
+					}
+
+					sent[event.Id+":"+channel]++
+					delivered = append(delivered, channel)
+				}
+
+				return delivered, nil
+			}
+
+			// Delivering the pending audit events.
+			for i := 0; i < tt.passes; i++ {
+				s.deliverPending(context.Background(), deliver)
+			}
+
+			var delivered int
+
+			for _, event := range repos.events {
+				if !event.DeliveredAt.IsZero() {
+					delivered++
+				}
+			}
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("error delivery attempts are not similar: %d", attempts)
+			}
+			if delivered != tt.wantDelivered {
+				t.Errorf("error delivered are not similar: %d", delivered)
+			}
+
+			var total int
+
+			for key, count := range sent {
+				if count != 1 {
+					t.Errorf("error audit event sent to %s %d times", key, count)
+				}
+
+				total += count
+			}
+
+			if total != tt.wantSent {
+				t.Errorf("error sent messages are not similar: %d", total)
+			}
+		})
+	}
+}
+
+// Checking is values contain the target.
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+
+	return false
+}
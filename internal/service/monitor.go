@@ -19,6 +19,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -37,39 +39,67 @@ type Monitor interface {
 	// Sync promo monitor with database.
 	Sync(ctx context.Context) error
 	// Using a promo code with monitor.
-	Use() (int, error)
+	Use() (MonitorUsage, error)
 	// De using promo code with monitor.
-	DeUse()
+	DeUse(usage MonitorUsage)
+	// Getting promo epochs table.
+	Epochs() []domain.Monitor
+	// Advancing the monitor to the next epoch.
+	Advance(ctx context.Context) (domain.Monitor, error)
+	// Setting the current epoch usage limit.
+	SetLimit(ctx context.Context, limit int) (domain.Monitor, error)
+	// Setting the current epoch reward.
+	SetReward(ctx context.Context, reward int) (domain.Monitor, error)
+	// Resetting the current epoch reward and usage limit to the epochs table.
+	Reset(ctx context.Context) (domain.Monitor, error)
+}
+
+// Promo code usage with monitor, it is used to de use the promo code.
+type MonitorUsage struct {
+	// Promo code reward.
+	Reward int
+	// Monitor state in which the promo code is used.
+	state int
 }
 
 // Monitor service structure.
 type MonitorService struct {
 	// Monitor repository.
 	repos repository.Monitor
+	// Audit service.
+	audit Audit
+	// Promo epochs table ordered by id.
+	epochs []domain.Monitor
 	// Monitor structure.
 	monitor *domain.Monitor
 	// Sync monitor mutex.
 	mutex sync.Mutex
 	// Updated monitor status.
 	updated bool
+	// Monitor state, it is changed when the epoch is advanced or replaced.
+	state int
 }
 
 // Creating a new monitor service.
-func NewMonitorService(repos repository.Monitor) *MonitorService {
-	return &MonitorService{repos: repos, mutex: sync.Mutex{}}
+func NewMonitorService(repos repository.Monitor, audit Audit, epochs []domain.Monitor) *MonitorService {
+	return &MonitorService{repos: repos, audit: audit, epochs: epochs, mutex: sync.Mutex{}}
 }
 
 // Getting a promo monitor.
 func (s *MonitorService) Get(ctx context.Context, id int, current, last bool) (domain.Monitor, error) {
-	if id > domain.MaxMonitorEpoch {
+	if id > len(s.epochs) {
 		return domain.Monitor{}, &domain.Error{
-			Code:    domain.CodeInvalidArgument,
-			Message: "There can be no more than 5 epochs.",
+			Code: domain.CodeInvalidArgument,
+			Key:  "errors.epochs-limit",
+			Data: domain.LimitData{Limit: len(s.epochs)},
 		}
 	}
 
 	// Checking is current options specified.
 	if current {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
 		return domain.Monitor{
 			Id:         s.monitor.Id,
 			Reward:     s.monitor.Reward,
@@ -82,12 +112,18 @@ func (s *MonitorService) Get(ctx context.Context, id int, current, last bool) (d
 	return s.repos.Get(ctx, id, last)
 }
 
-// Saving promo monitor.
+// Saving promo monitor. The current monitor is saved with the mutex locked, so
+// an older monitor does not overwrite a concurrent change.
 func (s *MonitorService) Save(ctx context.Context, skip bool, monitor ...domain.Monitor) error {
 	if monitor != nil {
 		// Updating promo monitor.
 		return s.repos.Update(ctx, monitor[0])
-	} else if s.updated || skip {
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.updated || skip {
 		// Updating promo monitor.
 		if err := s.repos.Update(ctx, domain.Monitor{
 			Id:         s.monitor.Id,
@@ -105,12 +141,28 @@ func (s *MonitorService) Save(ctx context.Context, skip bool, monitor ...domain.
 	return nil
 }
 
-// Sync promo monitor with database.
+// Sync promo monitor with database. If there is no monitor in the database,
+// the first epoch is started.
 func (s *MonitorService) Sync(ctx context.Context) error {
 	// Getting current promo monitor.
 	monitor, err := s.repos.Get(ctx, 0, true)
 	if err != nil {
-		return err
+		var e *domain.Error
+
+		if !errors.As(err, &e) || e.Code != domain.CodeNotFound {
+			return err
+		}
+
+		monitor = s.epochs[0]
+		monitor.StartedIn = time.Now()
+		monitor.UpdatedAt = time.Now()
+
+		s.updated = true
+	}
+
+	// Checking is monitor epoch in the epochs table.
+	if monitor.Id < 1 || monitor.Id > len(s.epochs) {
+		return fmt.Errorf("monitor epoch %d is not in the epochs table", monitor.Id)
 	}
 
 	s.monitor = &monitor
@@ -119,15 +171,16 @@ func (s *MonitorService) Sync(ctx context.Context) error {
 }
 
 // Using a promo code with monitor.
-func (s *MonitorService) Use() (int, error) {
+func (s *MonitorService) Use() (MonitorUsage, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	// Checking for the end of the limit of using the promo code in the epoch.
 	if s.monitor.UsageLimit == 0 {
 		// Checking is max epoch.
-		if s.monitor.Id == domain.MaxMonitorEpoch {
-			return 0, &domain.Error{Code: domain.CodeNotFound, Message: "Rewards are over!"}
+		next, ok := s.nextEpoch()
+		if !ok {
+			return MonitorUsage{}, &domain.Error{Code: domain.CodeResourceExhausted, Key: "errors.rewards-over"}
 		}
 
 		go func(mon domain.Monitor) {
@@ -141,27 +194,158 @@ func (s *MonitorService) Use() (int, error) {
 			}
 		}(*s.monitor)
 
-		monitor := domain.Epochs[s.monitor.Id+1]
+		s.monitor = &next
+		s.state++
 
-		s.monitor = &domain.Monitor{
-			Id:         monitor.Id,
-			Reward:     monitor.Reward,
-			UsageLimit: monitor.UsageLimit,
-			StartedIn:  time.Now(),
-			UpdatedAt:  time.Now(),
-		}
+		go func(mon domain.Monitor) {
+			// Recording the epoch advanced audit event.
+			if err := s.audit.Record(domain.AuditEvent{
+				Type:       domain.AuditEpochAdvanced,
+				Epoch:      mon.Id,
+				Amount:     mon.Reward,
+				UsageLimit: mon.UsageLimit,
+				CreatedAt:  mon.StartedIn,
+			}); err != nil {
+				log.Error().Err(err).Msg("failed to record audit event")
+			}
+		}(*s.monitor)
 	}
 
 	s.monitor.UsageLimit--
 	s.updated = true
 
-	return s.monitor.Reward, nil
+	return MonitorUsage{Reward: s.monitor.Reward, state: s.state}, nil
 }
 
-// De using promo code with monitor.
-func (s *MonitorService) DeUse() {
+// De using promo code with monitor. If the epoch has been advanced or replaced
+// since the usage, the usage limit is not changed.
+func (s *MonitorService) DeUse(usage MonitorUsage) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	// Checking is monitor changed since the usage.
+	if usage.state != s.state {
+		return
+	}
+
 	s.monitor.UsageLimit++
+	s.updated = true
+}
+
+// Getting promo epochs table.
+func (s *MonitorService) Epochs() []domain.Monitor {
+	return s.epochs
+}
+
+// Advancing the monitor to the next epoch. The current and the next epochs
+// are saved immediately.
+func (s *MonitorService) Advance(ctx context.Context) (domain.Monitor, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Checking is max epoch.
+	next, ok := s.nextEpoch()
+	if !ok {
+		return domain.Monitor{}, &domain.Error{
+			Code: domain.CodeFailedPrecondition,
+			Key:  "errors.last-epoch",
+		}
+	}
+
+	current := *s.monitor
+	current.UpdatedAt = time.Now()
+
+	// Saving the current promo monitor.
+	if err := s.repos.Update(ctx, current); err != nil {
+		return domain.Monitor{}, err
+	}
+
+	return next, s.replace(ctx, next)
+}
+
+// Setting the current epoch usage limit, it is saved immediately.
+func (s *MonitorService) SetLimit(ctx context.Context, limit int) (domain.Monitor, error) {
+	if limit < 0 {
+		return domain.Monitor{}, &domain.Error{
+			Code: domain.CodeInvalidArgument,
+			Key:  "errors.negative-limit",
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	monitor := *s.monitor
+	monitor.UsageLimit = limit
+	monitor.UpdatedAt = time.Now()
+
+	return monitor, s.replace(ctx, monitor)
+}
+
+// Setting the current epoch reward, it is saved immediately.
+func (s *MonitorService) SetReward(ctx context.Context, reward int) (domain.Monitor, error) {
+	if reward <= 0 {
+		return domain.Monitor{}, &domain.Error{
+			Code: domain.CodeInvalidArgument,
+			Key:  "errors.invalid-reward",
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	monitor := *s.monitor
+	monitor.Reward = reward
+	monitor.UpdatedAt = time.Now()
+
+	return monitor, s.replace(ctx, monitor)
+}
+
+// Resetting the current epoch reward and usage limit to the epochs table, it
+// is saved immediately.
+func (s *MonitorService) Reset(ctx context.Context) (domain.Monitor, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	epoch := s.epochs[s.monitor.Id-1]
+
+	monitor := *s.monitor
+	monitor.Reward = epoch.Reward
+	monitor.UsageLimit = epoch.UsageLimit
+	monitor.UpdatedAt = time.Now()
+
+	return monitor, s.replace(ctx, monitor)
+}
+
+// Getting the next epoch monitor, it returns false if the current epoch is
+// the last.
+func (s *MonitorService) nextEpoch() (domain.Monitor, bool) {
+	if s.monitor.Id >= len(s.epochs) {
+		return domain.Monitor{}, false
+	}
+
+	epoch := s.epochs[s.monitor.Id]
+
+	return domain.Monitor{
+		Id:         epoch.Id,
+		Reward:     epoch.Reward,
+		UsageLimit: epoch.UsageLimit,
+		StartedIn:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, true
+}
+
+// Saving the promo monitor and replacing the current monitor with it, the
+// monitor mutex must be locked.
+func (s *MonitorService) replace(ctx context.Context, monitor domain.Monitor) error {
+	// Updating promo monitor.
+	if err := s.repos.Update(ctx, monitor); err != nil {
+		return err
+	}
+
+	s.monitor = &monitor
+	s.updated = false
+	s.state++
+
+	return nil
 }
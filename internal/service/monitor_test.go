@@ -0,0 +1,302 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/internal/domain"
+)
+
+// In-memory monitor repository without saved monitors.
+type monitorRepository struct {
+	mutex   sync.Mutex
+	updates []domain.Monitor
+}
+
+// Getting promo monitor.
+func (r *monitorRepository) Get(ctx context.Context, id int, last bool) (domain.Monitor, error) {
+	return domain.Monitor{}, &domain.Error{Code: domain.CodeNotFound, Key: "errors.epoch-not-found"}
+}
+
+// Updating promo monitor.
+func (r *monitorRepository) Update(ctx context.Context, monitor domain.Monitor) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.updates = append(r.updates, monitor)
+	return nil
+}
+
+// Test using promo codes with the monitor epochs table.
+func TestMonitorService_Use(t *testing.T) {
+	epochs := []domain.Monitor{
+		{Id: 1, Reward: 100, UsageLimit: 1},
+		{Id: 2, Reward: 50, UsageLimit: 2},
+	}
+
+	s := NewMonitorService(&monitorRepository{}, NewAuditService(&auditRepository{}), epochs)
+
+	// Sync promo monitor, the first epoch is started.
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("error sync monitor: %s", err.Error())
+	}
+
+	// Using promo codes until the rewards are over.
+	for _, want := range []int{100, 50, 50} {
+		usage, err := s.Use()
+		if err != nil {
+			t.Fatalf("error using promo code: %s", err.Error())
+		}
+
+		if usage.Reward != want {
+			t.Errorf("error reward are not similar: %d", usage.Reward)
+		}
+	}
+
+	var e *domain.Error
+
+	if _, err := s.Use(); !errors.As(err, &e) || e.Code != domain.CodeResourceExhausted {
+		t.Errorf("error expected resource exhausted: %v", err)
+	}
+
+	if _, err := s.Get(context.Background(), 3, false, false); !errors.As(err, &e) ||
+		e.Key != "errors.epochs-limit" || e.Data != (domain.LimitData{Limit: 2}) {
+		t.Errorf("error expected invalid epoch: %v", err)
+	}
+}
+
+// Test managing the current epoch, every change is saved immediately.
+func TestMonitorService_Admin(t *testing.T) {
+	epochs := []domain.Monitor{
+		{Id: 1, Reward: 100, UsageLimit: 1},
+		{Id: 2, Reward: 50, UsageLimit: 2},
+	}
+
+	repos := &monitorRepository{}
+	s := NewMonitorService(repos, NewAuditService(&auditRepository{}), epochs)
+
+	// Sync promo monitor, the first epoch is started.
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("error sync monitor: %s", err.Error())
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name     string
+		action   func() (domain.Monitor, error)
+		want     domain.Monitor
+		wantErr  bool
+		wantCode domain.Code
+	}{
+		{
+			name:   "Set Limit",
+			action: func() (domain.Monitor, error) { return s.SetLimit(context.Background(), 10) },
+			want:   domain.Monitor{Id: 1, Reward: 100, UsageLimit: 10},
+		},
+		{
+			name:   "Set Reward",
+			action: func() (domain.Monitor, error) { return s.SetReward(context.Background(), 70) },
+			want:   domain.Monitor{Id: 1, Reward: 70, UsageLimit: 10},
+		},
+		{
+			name:     "Set Invalid Reward",
+			action:   func() (domain.Monitor, error) { return s.SetReward(context.Background(), 0) },
+			want:     domain.Monitor{Id: 1, Reward: 70, UsageLimit: 10},
+			wantErr:  true,
+			wantCode: domain.CodeInvalidArgument,
+		},
+		{
+			name:   "Reset",
+			action: func() (domain.Monitor, error) { return s.Reset(context.Background()) },
+			want:   domain.Monitor{Id: 1, Reward: 100, UsageLimit: 1},
+		},
+		{
+			name:   "Advance",
+			action: func() (domain.Monitor, error) { return s.Advance(context.Background()) },
+			want:   domain.Monitor{Id: 2, Reward: 50, UsageLimit: 2},
+		},
+		{
+			name:     "Advance Last",
+			action:   func() (domain.Monitor, error) { return s.Advance(context.Background()) },
+			want:     domain.Monitor{Id: 2, Reward: 50, UsageLimit: 2},
+			wantErr:  true,
+			wantCode: domain.CodeFailedPrecondition,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.action()
+
+			var e *domain.Error
+
+			if (err != nil) != tt.wantErr || (tt.wantErr && (!errors.As(err, &e) || e.Code != tt.wantCode)) {
+				t.Fatalf("error managing epoch: %v", err)
+			}
+
+			got := repos.updates[len(repos.updates)-1]
+
+			// Check for similarity of a saved monitor.
+			if got.Id != tt.want.Id || got.Reward != tt.want.Reward || got.UsageLimit != tt.want.UsageLimit {
+				t.Errorf("error saved monitor are not similar: %v", got)
+			}
+		})
+	}
+}
+
+// Test de using promo codes after the epoch changes.
+func TestMonitorService_DeUse(t *testing.T) {
+	epochs := []domain.Monitor{
+		{Id: 1, Reward: 100, UsageLimit: 5},
+		{Id: 2, Reward: 50, UsageLimit: 5},
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name      string
+		action    func(s *MonitorService) error
+		wantLimit int
+	}{
+		{
+			name:      "OK",
+			action:    func(s *MonitorService) error { return nil },
+			wantLimit: 5,
+		},
+		{
+			name: "Reset",
+			action: func(s *MonitorService) error {
+				_, err := s.Reset(context.Background())
+				return err
+			},
+			wantLimit: 5,
+		},
+		{
+			name: "Advance",
+			action: func(s *MonitorService) error {
+				_, err := s.Advance(context.Background())
+				return err
+			},
+			wantLimit: 5,
+		},
+		{
+			name: "Set Limit",
+			action: func(s *MonitorService) error {
+				_, err := s.SetLimit(context.Background(), 3)
+				return err
+			},
+			wantLimit: 3,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMonitorService(&monitorRepository{}, NewAuditService(&auditRepository{}), epochs)
+
+			// Sync promo monitor, the first epoch is started.
+			if err := s.Sync(context.Background()); err != nil {
+				t.Fatalf("error sync monitor: %s", err.Error())
+			}
+
+			usage, err := s.Use()
+			if err != nil {
+				t.Fatalf("error using promo code: %s", err.Error())
+			}
+
+			if err := tt.action(s); err != nil {
+				t.Fatalf("error managing epoch: %s", err.Error())
+			}
+
+			s.DeUse(usage)
+
+			got, err := s.Get(context.Background(), 0, true, false)
+			if err != nil {
+				t.Fatalf("error getting monitor: %s", err.Error())
+			}
+
+			if got.UsageLimit != tt.wantLimit {
+				t.Errorf("error usage limit are not similar: %d", got.UsageLimit)
+			}
+		})
+	}
+}
+
+// Test saving the promo monitor concurrently with the epoch changes, the last
+// saved monitor must be the current monitor.
+func TestMonitorService_SaveConcurrent(t *testing.T) {
+	epochs := []domain.Monitor{{Id: 1, Reward: 100, UsageLimit: 1000}}
+
+	repos := &monitorRepository{}
+	s := NewMonitorService(repos, NewAuditService(&auditRepository{}), epochs)
+
+	// Sync promo monitor, the first epoch is started.
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("error sync monitor: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := s.Use(); err != nil {
+				t.Errorf("error using promo code: %s", err.Error())
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if err := s.Save(context.Background(), false); err != nil {
+				t.Errorf("error saving monitor: %s", err.Error())
+			}
+		}()
+
+		go func(limit int) {
+			defer wg.Done()
+
+			if _, err := s.SetLimit(context.Background(), limit); err != nil {
+				t.Errorf("error setting limit: %s", err.Error())
+			}
+		}(500 + i)
+	}
+
+	wg.Wait()
+
+	// Saving the current monitor.
+	if err := s.Save(context.Background(), false); err != nil {
+		t.Fatalf("error saving monitor: %s", err.Error())
+	}
+
+	current, err := s.Get(context.Background(), 0, true, false)
+	if err != nil {
+		t.Fatalf("error getting monitor: %s", err.Error())
+	}
+
+	if got := repos.updates[len(repos.updates)-1]; got.UsageLimit != current.UsageLimit {
+		t.Errorf("error saved usage limit are not similar: %d != %d", got.UsageLimit, current.UsageLimit)
+	}
+}
@@ -18,6 +18,7 @@
 package service
 
 import (
+	"github.com/durudex/discord-promo-bot/internal/domain"
 	"github.com/durudex/discord-promo-bot/internal/repository"
 )
 
@@ -25,14 +26,17 @@ import (
 type Service struct {
 	User    User
 	Monitor Monitor
+	Audit   Audit
 }
 
-// Creating a new service.
-func NewService(repos *repository.Repository) *Service {
-	monitorService := NewMonitorService(repos.Monitor)
+// Creating a new service with the promo epochs table.
+func NewService(repos *repository.Repository, epochs []domain.Monitor) *Service {
+	auditService := NewAuditService(repos.Audit)
+	monitorService := NewMonitorService(repos.Monitor, auditService, epochs)
 
 	return &Service{
 		User:    NewUserService(repos.User, monitorService),
 		Monitor: monitorService,
+		Audit:   auditService,
 	}
 }
@@ -74,18 +74,18 @@ func (s *UserService) Update(ctx context.Context, user domain.User) error {
 // Using a user promo.
 func (s *UserService) UsePromo(ctx context.Context, discordId, promo string) (int, error) {
 	// Using a promo code with monitor.
-	reward, err := s.monitor.Use()
+	usage, err := s.monitor.Use()
 	if err != nil {
 		return 0, err
 	}
 
 	// Using a promo code.
-	if err := s.repos.UsePromo(ctx, discordId, promo, reward); err != nil {
-		s.monitor.DeUse()
+	if err := s.repos.UsePromo(ctx, discordId, promo, usage.Reward); err != nil {
+		s.monitor.DeUse(usage)
 		return 0, err
 	}
 
-	return reward, nil
+	return usage.Reward, nil
 }
 
 // Updating a user balance.
@@ -0,0 +1,118 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+// Max number of autocomplete choices allowed by discord.
+const maxAutocompleteChoices int = 25
+
+// Discord application command option autocomplete handler. The option is the
+// focused option with the value currently typed by the user.
+type AutocompleteHandler func(
+	ctx context.Context,
+	s Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) []*discordgo.ApplicationCommandOptionChoice
+
+// Registering discord application command autocomplete handlers. All option
+// paths are checked before the command is changed, so a failed registration
+// leaves no autocomplete handlers.
+func (b *Bot) registerAutocomplete(c *Command) error {
+	options := make(map[string]*discordgo.ApplicationCommandOption, len(c.Autocomplete))
+
+	for path := range c.Autocomplete {
+		// Getting a application command option by path.
+		option := findOption(c.Options, strings.Fields(path))
+		if option == nil {
+			return fmt.Errorf("command %s has no option %s", c.Name, path)
+		}
+
+		options[path] = option
+	}
+
+	for path, handler := range c.Autocomplete {
+		options[path].Autocomplete = true
+
+		b.autocompletes[c.Name+" "+strings.Join(strings.Fields(path), " ")] = handler
+	}
+
+	return nil
+}
+
+// Handle discord application command autocomplete.
+func (b *Bot) handleAutocomplete(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+	path, options := CommandPath(i.ApplicationCommandData())
+
+	for _, option := range options {
+		if !option.Focused {
+			continue
+		}
+
+		handler, ok := b.autocompletes[path+" "+option.Name]
+		if !ok {
+			return
+		}
+
+		choices := handler(ctx, s, i, option)
+		if len(choices) > maxAutocompleteChoices {
+			choices = choices[:maxAutocompleteChoices]
+		}
+
+		// Send a interaction autocomplete result.
+		if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{Choices: choices},
+		}); err != nil {
+			log.Warn().Err(err).Msg("failed to send interaction autocomplete result")
+		}
+
+		return
+	}
+}
+
+// Getting a discord application command option by path, the last element of
+// the path is the option name and the rest are sub commands.
+func findOption(options []*discordgo.ApplicationCommandOption, path []string) *discordgo.ApplicationCommandOption {
+	if len(path) == 0 {
+		return nil
+	}
+
+	for _, option := range options {
+		if option.Name != path[0] {
+			continue
+		}
+
+		// Checking is option is a sub command.
+		if isSubCommand(option.Type) {
+			return findOption(option.Options, path[1:])
+		} else if len(path) == 1 {
+			return option
+		}
+	}
+
+	return nil
+}
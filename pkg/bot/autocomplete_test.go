@@ -0,0 +1,154 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package bot_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Testing autocomplete handler, it suggests the typed value.
+func suggest(
+	ctx context.Context,
+	s bot.Session,
+	i *discordgo.InteractionCreate,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+) []*discordgo.ApplicationCommandOptionChoice {
+	return []*discordgo.ApplicationCommandOptionChoice{{Name: option.Name, Value: option.Value}}
+}
+
+// Creating a new testing application command with a sub command.
+func autocompleteCommand() discordgo.ApplicationCommand {
+	return discordgo.ApplicationCommand{
+		Name:        "epoch",
+		Description: "Epoch.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionInteger, Name: "epoch", Description: "Epoch."},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reward",
+				Description: "Reward.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{Type: discordgo.ApplicationCommandOptionInteger, Name: "epoch", Description: "Epoch."},
+				},
+			},
+		},
+	}
+}
+
+// Test handling application command autocomplete interactions.
+func TestBot_Autocomplete(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		interaction   *discordgo.InteractionCreate
+		wantResponses int
+		wantChoices   []any
+	}{
+		{
+			name:          "Option",
+			interaction:   bottest.Autocomplete("epoch", bottest.Option("epoch", discordgo.ApplicationCommandOptionInteger, "1")),
+			wantResponses: 1,
+			wantChoices:   []any{"1"},
+		},
+		{
+			name: "Sub Command Option",
+			interaction: bottest.Autocomplete("epoch", &discordgo.ApplicationCommandInteractionDataOption{
+				Type: discordgo.ApplicationCommandOptionSubCommand,
+				Name: "reward",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Type: discordgo.ApplicationCommandOptionInteger, Name: "epoch", Value: "2", Focused: true},
+				},
+			}),
+			wantResponses: 1,
+			wantChoices:   []any{"2"},
+		},
+		{
+			name:        "Unknown Option",
+			interaction: bottest.Autocomplete("epoch", bottest.Option("user", discordgo.ApplicationCommandOptionUser, "")),
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bot.New(&bot.BotConfig{Token: "123"})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			// Registering a testing application command.
+			if err := b.RegisterCommand(&bot.Command{
+				ApplicationCommand: autocompleteCommand(),
+				Autocomplete:       map[string]bot.AutocompleteHandler{"epoch": suggest, "reward epoch": suggest},
+			}); err != nil {
+				t.Fatalf("error registering command: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction.
+			b.Handle(s, tt.interaction)
+
+			if responses := s.Responses(); len(responses) != tt.wantResponses {
+				t.Fatalf("error unexpected responses: %v", responses)
+			}
+
+			if tt.wantChoices != nil {
+				choices := make([]any, 0)
+
+				for _, choice := range s.Message().Choices {
+					choices = append(choices, choice.Value)
+				}
+
+				// Check for similarity of a choices.
+				if !reflect.DeepEqual(choices, tt.wantChoices) {
+					t.Errorf("error choices are not similar: %v", choices)
+				}
+			}
+		})
+	}
+}
+
+// Test registering application command with an unknown autocomplete option.
+func TestBot_RegisterAutocomplete(t *testing.T) {
+	b, err := bot.New(&bot.BotConfig{Token: "123"})
+	if err != nil {
+		t.Fatalf("error creating bot: %s", err.Error())
+	}
+
+	command := autocompleteCommand()
+
+	// Registering a testing application command.
+	if err := b.RegisterCommand(&bot.Command{
+		ApplicationCommand: command,
+		Autocomplete:       map[string]bot.AutocompleteHandler{"epoch": suggest, "unknown": suggest},
+	}); err == nil {
+		t.Fatal("error expected unknown option error")
+	}
+
+	// Checking is command options not changed.
+	if command.Options[0].Autocomplete {
+		t.Error("error option autocomplete are changed")
+	}
+}
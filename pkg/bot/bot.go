@@ -17,31 +17,158 @@
 
 package bot
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+// Discord interaction token lifetime, after which it is no longer possible to
+// respond to the interaction.
+const InteractionTimeout time.Duration = time.Minute * 15
+
+// Discord bot config structure.
+type BotConfig struct {
+	// Discord bot token.
+	Token string
+	// Discord guilds in which application commands are registered. If
+	// the list is empty, application commands are registered globally.
+	Guilds []string
+	// Registering application commands globally in addition to the guilds.
+	Global bool
+	// Interaction handling timeout. If the timeout is not specified or is
+	// greater than the interaction token lifetime, the lifetime is used.
+	Timeout time.Duration
+	// Threshold after which the interaction response is deferred. If the
+	// threshold is not specified, the default threshold is used.
+	DeferThreshold time.Duration
+	// Application commands localizer. If the localizer is not specified,
+	// application commands are not localized.
+	Localizer Localizer
+	// Application commands cooldowns config. If the config is not
+	// specified, application commands are not limited.
+	Cooldown *CooldownConfig
+	// Interaction handler panic reporter. If the reporter is not specified,
+	// a default error message is sent.
+	PanicReporter PanicReporter
+}
+
+// Interaction handler panic reporter, it responds to the interaction with the
+// panic error through the interaction responder.
+type PanicReporter func(r *Responder, err error)
+
+// Discord application commands localizer interface.
+type Localizer interface {
+	// Localizing a discord application command definition.
+	LocalizeCommand(cmd *discordgo.ApplicationCommand)
+}
 
 // Bot structure.
 type Bot struct {
 	// Discord bot session.
 	session *discordgo.Session
+	// Discord guilds in which application commands are registered.
+	guilds []string
+	// Interaction handling timeout.
+	timeout time.Duration
+	// Threshold after which the interaction response is deferred.
+	deferThreshold time.Duration
+	// Timeout after which the interactions endpoint defers the response.
+	responseTimeout time.Duration
+	// Application commands localizer.
+	localizer Localizer
+	// Application commands cooldowns.
+	cooldowns *cooldowns
+	// Interaction handler panic reporter.
+	panicReporter PanicReporter
+	// Bot context, it is canceled when the bot is closed.
+	ctx context.Context
+	// Cancel bot context function.
+	cancel context.CancelFunc
+	// Bot closed state mutex.
+	mutex sync.RWMutex
+	// Bot closed status, interactions are not handled after closing.
+	closed bool
+	// Interactions in handling.
+	wg sync.WaitGroup
+	// Interaction responders in handling by interaction id.
+	responders sync.Map
 	// Discord bot application commands.
 	commands map[string]*Command
+	// Discord bot application commands handlers by command path.
+	handlers map[string]HandlerFunc
+	// Discord bot application commands autocomplete handlers by option path.
+	autocompletes map[string]AutocompleteHandler
 	// Discord bot application commands components.
-	components map[string]*Component
+	components *router[*Component]
+	// Discord bot modals.
+	modals *router[*Modal]
+	// Discord bot global middlewares.
+	middlewares []Middleware
+	// Registered discord bot plugins.
+	plugins []Plugin
+	// Interactions endpoint server, it is used in the interactions endpoint
+	// mode.
+	server *http.Server
 }
 
 // Creating a new discord bot.
-func New(token string) (*Bot, error) {
+func New(cfg *BotConfig) (*Bot, error) {
 	// Create a new Discord session using the provided bot token.
-	session, err := discordgo.New("Bot " + token)
+	session, err := discordgo.New("Bot " + cfg.Token)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Bot{
-		session:    session,
-		commands:   make(map[string]*Command),
-		components: make(map[string]*Component),
-	}, nil
+	// Checking is global mode, the empty guild id is the global scope.
+	guilds := append(make([]string, 0, len(cfg.Guilds)+1), cfg.Guilds...)
+	if len(guilds) == 0 || cfg.Global {
+		guilds = append(guilds, "")
+	}
+
+	// Checking is timeout bounded by the interaction token lifetime.
+	timeout := cfg.Timeout
+	if timeout <= 0 || timeout > InteractionTimeout {
+		timeout = InteractionTimeout
+	}
+
+	// Checking is defer threshold specified.
+	deferThreshold := cfg.DeferThreshold
+	if deferThreshold <= 0 {
+		deferThreshold = DefaultDeferThreshold
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &Bot{
+		session:         session,
+		guilds:          guilds,
+		localizer:       cfg.Localizer,
+		panicReporter:   cfg.PanicReporter,
+		timeout:         timeout,
+		deferThreshold:  deferThreshold,
+		responseTimeout: initialResponseTimeout,
+		ctx:             ctx,
+		cancel:          cancel,
+		commands:        make(map[string]*Command),
+		handlers:        make(map[string]HandlerFunc),
+		autocompletes:   make(map[string]AutocompleteHandler),
+		components:      newRouter[*Component](),
+		modals:          newRouter[*Modal](),
+	}
+
+	// Checking is cooldowns config specified.
+	if cfg.Cooldown != nil {
+		b.cooldowns = newCooldowns(cfg.Cooldown)
+	}
+
+	return b, nil
 }
 
 // Running the discord bot.
@@ -49,28 +176,146 @@ func (b *Bot) Run() error {
 	return b.session.Open()
 }
 
-// Closing a bot connections.
+// Closing a bot connections. New interactions are no longer handled, the
+// contexts of interactions in handling are canceled and the bot waits for
+// their handlers to finish, after that the plugins are stopped.
 func (b *Bot) Close() error {
+	b.mutex.Lock()
+	b.closed = true
+	b.mutex.Unlock()
+
+	b.cancel()
+
+	// Closing the interactions endpoint server.
+	if err := b.closeHTTP(); err != nil {
+		return err
+	}
+
+	b.wg.Wait()
+
+	// Stopping all discord bot plugins.
+	if err := b.stopPlugins(context.Background()); err != nil {
+		return err
+	}
+
 	return b.session.Close()
 }
 
+// Getting the discord bot session, it is used to send messages outside of
+// interaction handlers.
+func (b *Bot) Session() Session {
+	return b.session
+}
+
 // Registering a discord bot handler.
 func (b *Bot) RegisterHandler(handler any) func() {
 	return b.session.AddHandler(handler)
 }
 
 // Handle discord application command.
-func (b *Bot) Handle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (b *Bot) Handle(s Session, i *discordgo.InteractionCreate) {
+	// Checking is bot closed.
+	if !b.acquire() {
+		return
+	}
+	defer b.wg.Done()
+
+	// Creating a new interaction context.
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+
+	// Stopping the interaction responder after handling.
+	defer b.release(i)
+
+	// Recovering handler panic.
+	defer b.recover(s, i)
+
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
+		data := i.ApplicationCommandData()
+		path, _ := CommandPath(data)
+
 		// Handle discord bot application command.
-		if c, ok := b.commands[i.ApplicationCommandData().Name]; ok {
-			c.Handler(s, i)
+		if handler, ok := b.handlers[handlerKey(interactionCommandType(data), path)]; ok {
+			Chain(handler, b.middlewares...)(ctx, s, i)
 		}
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		// Handle discord bot application command autocomplete.
+		b.handleAutocomplete(ctx, s, i)
 	case discordgo.InteractionMessageComponent:
 		// Handle discord message component.
-		if c, ok := b.components[i.MessageComponentData().CustomID]; ok {
-			c.Handler(s, i)
+		if c, params, ok := b.components.Match(i.MessageComponentData().CustomID); ok {
+			handler := func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+				c.Handler(ctx, s, i, params)
+			}
+
+			Chain(Chain(handler, c.Middlewares...), b.middlewares...)(ctx, s, i)
+		}
+	case discordgo.InteractionModalSubmit:
+		// Handle discord modal submit.
+		if m, params, ok := b.modals.Match(i.ModalSubmitData().CustomID); ok {
+			handler := func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+				m.Handler(ctx, s, i, params, modalFields(i.ModalSubmitData()))
+			}
+
+			Chain(Chain(handler, m.Middlewares...), b.middlewares...)(ctx, s, i)
 		}
 	}
 }
+
+// Adding the interaction to the interactions in handling, it is not added if
+// the bot is closed.
+func (b *Bot) acquire() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	// Checking is bot closed.
+	if b.closed {
+		return false
+	}
+
+	b.wg.Add(1)
+
+	return true
+}
+
+// Releasing the interaction responder, the response is no longer deferred
+// automatically after the interaction handling.
+func (b *Bot) release(i *discordgo.InteractionCreate) {
+	if r, ok := b.responders.LoadAndDelete(i.ID); ok {
+		r.(*Responder).Stop()
+	}
+}
+
+// Recovering interaction handler panic and reporting it through the
+// interaction responder, so the panic is reported even if the response has
+// already been deferred.
+func (b *Bot) recover(s Session, i *discordgo.InteractionCreate) {
+	v := recover()
+	if v == nil {
+		return
+	}
+
+	log.Error().Interface("panic", v).Bytes("stack", debug.Stack()).Msg("interaction handler panic")
+
+	// Autocomplete interaction can only be responded with choices.
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		return
+	}
+
+	r := b.NewResponder(s, i)
+
+	// Checking is panic reporter specified.
+	if b.panicReporter != nil {
+		b.panicReporter(r, fmt.Errorf("interaction handler panic: %v", v))
+		return
+	}
+
+	// Send a interaction respond error message.
+	if err := r.Respond(&discordgo.InteractionResponseData{
+		Content: "Internal bot error",
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}); err != nil {
+		log.Warn().Err(err).Msg("failed to send interaction respond error message")
+	}
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package bot_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test handling interactions with the interaction context and recovering
+// handler panics.
+func TestBot_Handle(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		reporter      bool
+		handler       func(b *bot.Bot) bot.HandlerFunc
+		wantResponses int
+		wantContent   string
+		wantFollowups int
+		wantReported  bool
+	}{
+		{
+			name: "Deadline",
+			handler: func(b *bot.Bot) bot.HandlerFunc {
+				return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+					// Checking is context bounded by the timeout.
+					if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Second {
+						t.Errorf("error unexpected deadline: %s", deadline)
+					}
+				}
+			},
+		},
+		{
+			name: "Panic",
+			handler: func(b *bot.Bot) bot.HandlerFunc {
+				return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+					panic("test")
+				}
+			},
+			wantResponses: 1,
+			wantContent:   "Internal bot error",
+		},
+		{
+			name: "Panic Deferred",
+			handler: func(b *bot.Bot) bot.HandlerFunc {
+				return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+					// Deferring the interaction response.
+					if err := b.NewResponder(s, i).Defer(); err != nil {
+						t.Errorf("error deferring response: %s", err.Error())
+					}
+
+					panic("test")
+				}
+			},
+			wantResponses: 1,
+			wantFollowups: 1,
+		},
+		{
+			name:     "Panic Reporter",
+			reporter: true,
+			handler: func(b *bot.Bot) bot.HandlerFunc {
+				return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+					panic("test")
+				}
+			},
+			wantReported: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported bool
+
+			cfg := &bot.BotConfig{Token: "123", Timeout: time.Second}
+
+			// Checking is panic reporter used.
+			if tt.reporter {
+				cfg.PanicReporter = func(r *bot.Responder, err error) { reported = err != nil }
+			}
+
+			b, err := bot.New(cfg)
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			// Registering a testing application command.
+			if err := b.RegisterCommand(&bot.Command{
+				ApplicationCommand: discordgo.ApplicationCommand{Name: "test", Description: "Test."},
+				Handler:            tt.handler(b),
+			}); err != nil {
+				t.Fatalf("error registering command: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction.
+			b.Handle(s, bottest.Command("test"))
+
+			if responses := s.Responses(); len(responses) != tt.wantResponses {
+				t.Errorf("error unexpected responses: %v", responses)
+			}
+			if tt.wantContent != "" && s.Message().Content != tt.wantContent {
+				t.Errorf("error content are not similar: %s", s.Message().Content)
+			}
+			if followups := s.Followups(); len(followups) != tt.wantFollowups {
+				t.Errorf("error unexpected follow-up messages: %d", len(followups))
+			}
+			if reported != tt.wantReported {
+				t.Errorf("error reported are not similar: %t", reported)
+			}
+		})
+	}
+}
+
+// Test closing the bot with interactions in handling.
+func TestBot_Close(t *testing.T) {
+	b, err := bot.New(&bot.BotConfig{Token: "123"})
+	if err != nil {
+		t.Fatalf("error creating bot: %s", err.Error())
+	}
+
+	var (
+		started = make(chan struct{})
+		done    = make(chan error, 1)
+		calls   int
+	)
+
+	// Registering a testing application command, it waits for the context
+	// cancellation.
+	if err := b.RegisterCommand(&bot.Command{
+		ApplicationCommand: discordgo.ApplicationCommand{Name: "test", Description: "Test."},
+		Handler: func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+			calls++
+			close(started)
+
+			<-ctx.Done()
+			done <- ctx.Err()
+		},
+	}); err != nil {
+		t.Fatalf("error registering command: %s", err.Error())
+	}
+
+	go b.Handle(bottest.NewSession(), bottest.Command("test"))
+	<-started
+
+	// Closing the bot, it waits for the interaction handling.
+	if err := b.Close(); err != nil {
+		t.Fatalf("error closing bot: %s", err.Error())
+	}
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("error context is not canceled: %v", err)
+	}
+
+	// Handling the interaction after closing.
+	b.Handle(bottest.NewSession(), bottest.Command("test"))
+
+	if calls != 1 {
+		t.Errorf("error interaction handled after closing: %d", calls)
+	}
+}
+
+// Test reserving the application command cooldowns after the command
+// middlewares.
+func TestBot_Cooldown(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		reject        bool
+		wantCalls     int
+		wantResponses int
+	}{
+		{
+			name:          "Handled",
+			wantCalls:     1,
+			wantResponses: 1,
+		},
+		{
+			name:   "Middleware Rejected",
+			reject: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bot.New(&bot.BotConfig{
+				Token: "123",
+				Cooldown: &bot.CooldownConfig{
+					Commands: map[string]bot.Cooldown{"test": {User: time.Minute}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			var calls int
+
+			// Registering a testing application command.
+			if err := b.RegisterCommand(&bot.Command{
+				ApplicationCommand: discordgo.ApplicationCommand{Name: "test", Description: "Test."},
+				Handler: func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+					calls++
+				},
+				Middlewares: []bot.Middleware{
+					func(next bot.HandlerFunc) bot.HandlerFunc {
+						return func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+							if !tt.reject {
+								next(ctx, s, i)
+							}
+						}
+					},
+				},
+			}); err != nil {
+				t.Fatalf("error registering command: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction twice.
+			b.Handle(s, bottest.Command("test"))
+			b.Handle(s, bottest.Command("test"))
+
+			if calls != tt.wantCalls {
+				t.Errorf("error calls are not similar: %d", calls)
+			}
+			if responses := s.Responses(); len(responses) != tt.wantResponses {
+				t.Errorf("error unexpected responses: %v", responses)
+			}
+		})
+	}
+}
+
+// Test reserving the application command cooldowns by concurrent interactions.
+func TestBot_CooldownConcurrent(t *testing.T) {
+	b, err := bot.New(&bot.BotConfig{
+		Token: "123",
+		Cooldown: &bot.CooldownConfig{
+			Commands: map[string]bot.Cooldown{"test": {User: time.Minute}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating bot: %s", err.Error())
+	}
+
+	var calls int32
+
+	// Registering a testing application command.
+	if err := b.RegisterCommand(&bot.Command{
+		ApplicationCommand: discordgo.ApplicationCommand{Name: "test", Description: "Test."},
+		Handler: func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+			atomic.AddInt32(&calls, 1)
+		},
+	}); err != nil {
+		t.Fatalf("error registering command: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+
+	// Handling the interactions concurrently.
+	for n := 0; n < 5; n++ {
+		wg.Add(1)
+
+		go func(id string) {
+			defer wg.Done()
+
+			i := bottest.Command("test")
+			i.ID = id
+
+			b.Handle(bottest.NewSession(), i)
+		}(strconv.Itoa(n))
+	}
+
+	wg.Wait()
+
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("error calls are not similar: %d", calls)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bottest
+
+import "github.com/bwmarrin/discordgo"
+
+// Testing interaction author.
+var User = &discordgo.User{ID: "100000000000000000", Username: "durudex", Discriminator: "0001"}
+
+// Creating a new testing interaction created by the testing user in a guild.
+func newInteraction(t discordgo.InteractionType, data discordgo.InteractionData) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:      "200000000000000000",
+			Type:    t,
+			Data:    data,
+			GuildID: "300000000000000000",
+			Member:  &discordgo.Member{User: User},
+			Locale:  discordgo.EnglishUS,
+			Token:   "token",
+		},
+	}
+}
+
+// Creating a new testing application command interaction.
+func Command(name string, options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+	return newInteraction(discordgo.InteractionApplicationCommand, discordgo.ApplicationCommandInteractionData{
+		Name:     name,
+		Options:  options,
+		Resolved: &discordgo.ApplicationCommandInteractionDataResolved{},
+	})
+}
+
+// Creating a new testing user command interaction.
+func UserCommand(name string, target *discordgo.User) *discordgo.InteractionCreate {
+	return newInteraction(discordgo.InteractionApplicationCommand, discordgo.ApplicationCommandInteractionData{
+		Name:     name,
+		TargetID: target.ID,
+		Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+			Users: map[string]*discordgo.User{target.ID: target},
+		},
+	})
+}
+
+// Creating a new testing message command interaction.
+func MessageCommand(name string, target *discordgo.Message) *discordgo.InteractionCreate {
+	return newInteraction(discordgo.InteractionApplicationCommand, discordgo.ApplicationCommandInteractionData{
+		Name:     name,
+		TargetID: target.ID,
+		Resolved: &discordgo.ApplicationCommandInteractionDataResolved{
+			Messages: map[string]*discordgo.Message{target.ID: target},
+		},
+	})
+}
+
+// Creating a new testing autocomplete interaction, the option is focused.
+func Autocomplete(name string, option *discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+	option.Focused = true
+
+	i := Command(name, option)
+	i.Type = discordgo.InteractionApplicationCommandAutocomplete
+
+	return i
+}
+
+// Creating a new testing modal submit interaction.
+func ModalSubmit(customID string, fields map[string]string) *discordgo.InteractionCreate {
+	components := make([]discordgo.MessageComponent, 0, len(fields))
+
+	for id, value := range fields {
+		components = append(components, &discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{&discordgo.TextInput{CustomID: id, Value: value}},
+		})
+	}
+
+	return newInteraction(discordgo.InteractionModalSubmit, discordgo.ModalSubmitInteractionData{
+		CustomID:   customID,
+		Components: components,
+	})
+}
+
+// Creating a new testing application command option.
+func Option(
+	name string,
+	t discordgo.ApplicationCommandOptionType,
+	value any,
+) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: t, Value: value}
+}
+
+// Adding a user option to the testing application command interaction, the
+// user is added to the resolved data of the interaction.
+func AddUserOption(i *discordgo.InteractionCreate, name string, user *discordgo.User) {
+	data := i.Data.(discordgo.ApplicationCommandInteractionData)
+
+	if data.Resolved.Users == nil {
+		data.Resolved.Users = make(map[string]*discordgo.User)
+	}
+
+	data.Resolved.Users[user.ID] = user
+	data.Options = append(data.Options, Option(name, discordgo.ApplicationCommandOptionUser, user.ID))
+
+	i.Data = data
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package bottest provides an in-memory discord session and interaction
+// builders for testing interaction handlers.
+package bottest
+
+import (
+	"sync"
+
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Checking is fake session implements the bot session interface.
+var _ bot.Session = (*Session)(nil)
+
+// Fake discord session structure, it records interaction responses and
+// channel messages in memory.
+type Session struct {
+	// Session state mutex.
+	mutex sync.Mutex
+	// Interaction responses.
+	responses []*discordgo.InteractionResponse
+	// Current interaction response message, it includes response edits.
+	message discordgo.InteractionResponseData
+	// Interaction follow-up messages.
+	followups []*discordgo.WebhookParams
+	// Channel embed messages by channel id.
+	messages map[string][]*discordgo.MessageEmbed
+	// Error returned by all session operations.
+	err error
+}
+
+// Creating a new fake discord session.
+func NewSession() *Session {
+	return &Session{messages: make(map[string][]*discordgo.MessageEmbed)}
+}
+
+// Setting an error returned by all session operations.
+func (s *Session) SetError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.err = err
+}
+
+// Recording the discord interaction response.
+func (s *Session) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.responses = append(s.responses, resp)
+
+	// Checking is response contains a message.
+	if resp.Data != nil {
+		s.message = *resp.Data
+	}
+
+	return nil
+}
+
+// Recording the original discord interaction response deletion.
+func (s *Session) InteractionResponseDelete(interaction *discordgo.Interaction) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.message = discordgo.InteractionResponseData{}
+
+	return nil
+}
+
+// Recording the original discord interaction response edit.
+func (s *Session) InteractionResponseEdit(
+	interaction *discordgo.Interaction,
+	newresp *discordgo.WebhookEdit,
+) (*discordgo.Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if newresp.Content != nil {
+		s.message.Content = *newresp.Content
+	}
+	if newresp.Embeds != nil {
+		s.message.Embeds = *newresp.Embeds
+	}
+
+	return &discordgo.Message{Content: s.message.Content, Embeds: s.message.Embeds}, nil
+}
+
+// Recording the discord interaction follow-up message.
+func (s *Session) FollowupMessageCreate(
+	interaction *discordgo.Interaction,
+	wait bool,
+	data *discordgo.WebhookParams,
+) (*discordgo.Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.followups = append(s.followups, data)
+
+	return &discordgo.Message{Content: data.Content, Embeds: data.Embeds}, nil
+}
+
+// Recording the discord channel embed message.
+func (s *Session) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed) (*discordgo.Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.messages[channelID] = append(s.messages[channelID], embed)
+
+	return &discordgo.Message{ChannelID: channelID, Embeds: []*discordgo.MessageEmbed{embed}}, nil
+}
+
+// Getting all recorded interaction responses.
+func (s *Session) Responses() []*discordgo.InteractionResponse {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*discordgo.InteractionResponse(nil), s.responses...)
+}
+
+// Getting the current interaction response message, edits of the deferred
+// response are taken into account.
+func (s *Session) Message() discordgo.InteractionResponseData {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.message
+}
+
+// Getting all recorded interaction follow-up messages.
+func (s *Session) Followups() []*discordgo.WebhookParams {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*discordgo.WebhookParams(nil), s.followups...)
+}
+
+// Getting all recorded embed messages of the channel.
+func (s *Session) ChannelMessages(channelID string) []*discordgo.MessageEmbed {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*discordgo.MessageEmbed(nil), s.messages[channelID]...)
+}
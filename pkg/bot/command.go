@@ -17,44 +17,370 @@
 
 package bot
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
 
 // Discord application command structure.
 type Command struct {
 	// Discord application command.
 	discordgo.ApplicationCommand
 	// Discord bot application command handler.
-	Handler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Handler HandlerFunc
+	// Discord bot application sub commands handlers by path relative to the
+	// command, for example "create" or "balance set".
+	SubCommands map[string]HandlerFunc
+	// Discord bot application command middlewares, they are applied to the
+	// command and all sub commands handlers after the global middlewares.
+	Middlewares []Middleware
+	// Discord bot application command options autocomplete handlers by option
+	// path relative to the command, for example "epoch" or "reward epoch".
+	Autocomplete map[string]AutocompleteHandler
+}
+
+// Discord application commands difference structure.
+type commandsDiff struct {
+	// Names of commands that need to be created.
+	Created []string
+	// Names of commands that need to be edited.
+	Updated []string
+	// Names of commands that need to be deleted.
+	Deleted []string
+	// Number of commands that have not changed.
+	Unchanged int
+}
+
+// Checking is there are changes in the commands difference.
+func (d commandsDiff) Changed() bool {
+	return len(d.Created) != 0 || len(d.Updated) != 0 || len(d.Deleted) != 0
 }
 
-// Registering a new discord application command.
+// Registering a new discord application command. The command is only saved in
+// the bot registry, it will be sent to discord when synchronizing commands.
 func (b *Bot) RegisterCommand(c *Command) error {
-	// Creating a new global discord application command.
-	cmd, err := b.session.ApplicationCommandCreate(b.session.State.User.ID, "", &c.ApplicationCommand)
-	if err != nil {
+	key := commandKey(&c.ApplicationCommand)
+
+	// Checking is command already registered.
+	if _, ok := b.commands[key]; ok {
+		return fmt.Errorf("command %s already registered", c.Name)
+	}
+
+	// Checking is context menu command has only a handler.
+	if commandType(c.Type) != discordgo.ChatApplicationCommand &&
+		(c.Description != "" || len(c.Options) != 0 || len(c.SubCommands) != 0 || len(c.Autocomplete) != 0) {
+		return fmt.Errorf("context menu command %s can't have description, options or sub commands", c.Name)
+	}
+
+	// Checking is sub commands exists in the command options.
+	for path := range c.SubCommands {
+		if !hasSubCommand(c.Options, strings.Fields(path)) {
+			return fmt.Errorf("command %s has no sub command %s", c.Name, path)
+		}
+	}
+
+	// Registering discord application command autocomplete handlers.
+	if err := b.registerAutocomplete(c); err != nil {
 		return err
 	}
 
 	// Save the discord application command.
-	b.commands[c.Name] = &Command{ApplicationCommand: *cmd, Handler: c.Handler}
+	b.commands[key] = c
+
+	// Save the discord application command handlers, the cooldowns are
+	// checked after the command middlewares.
+	if c.Handler != nil {
+		b.handlers[key] = Chain(b.cooldown(c.Name, c.Handler), c.Middlewares...)
+	}
+	for path, handler := range c.SubCommands {
+		path = c.Name + " " + strings.Join(strings.Fields(path), " ")
+		b.handlers[handlerKey(c.Type, path)] = Chain(b.cooldown(path, handler), c.Middlewares...)
+	}
+
+	return nil
+}
+
+// Getting the user on which the user context menu command was called.
+func TargetUser(i *discordgo.InteractionCreate) *discordgo.User {
+	data := i.ApplicationCommandData()
+
+	if data.Resolved == nil {
+		return nil
+	}
+
+	return data.Resolved.Users[data.TargetID]
+}
+
+// Getting the message on which the message context menu command was called.
+func TargetMessage(i *discordgo.InteractionCreate) *discordgo.Message {
+	data := i.ApplicationCommandData()
+
+	if data.Resolved == nil {
+		return nil
+	}
+
+	return data.Resolved.Messages[data.TargetID]
+}
+
+// Getting the full discord application command path, for example "admin
+// balance set", and the options of the last sub command in the path.
+func CommandPath(data discordgo.ApplicationCommandInteractionData) (string, []*discordgo.ApplicationCommandInteractionDataOption) {
+	path, options := data.Name, data.Options
+
+	for len(options) == 1 && isSubCommand(options[0].Type) {
+		path += " " + options[0].Name
+		options = options[0].Options
+	}
+
+	return path, options
+}
+
+// Synchronizing registered discord application commands with discord. Only
+// created, edited or deleted commands are changed by a bulk overwrite.
+func (b *Bot) SyncCommands() error {
+	desired := make([]*discordgo.ApplicationCommand, 0, len(b.commands))
+
+	for _, command := range b.commands {
+		cmd := command.ApplicationCommand
+
+		// Localizing the application command.
+		if b.localizer != nil {
+			b.localizer.LocalizeCommand(&cmd)
+		}
+
+		desired = append(desired, &cmd)
+	}
+
+	// Sorting commands by name for a stable order.
+	sort.Slice(desired, func(i, j int) bool { return desired[i].Name < desired[j].Name })
+
+	for _, guild := range b.guilds {
+		// Getting all discord application commands in the guild.
+		current, err := b.session.ApplicationCommands(b.session.State.User.ID, guild)
+		if err != nil {
+			return err
+		}
+
+		diff := diffCommands(desired, current)
+
+		// Checking is commands changed.
+		if diff.Changed() {
+			// Overwriting all discord application commands in the guild.
+			if _, err := b.session.ApplicationCommandBulkOverwrite(
+				b.session.State.User.ID,
+				guild,
+				desired,
+			); err != nil {
+				return err
+			}
+		}
+
+		log.Info().
+			Str("guild", guild).
+			Strs("created", diff.Created).
+			Strs("updated", diff.Updated).
+			Strs("deleted", diff.Deleted).
+			Int("unchanged", diff.Unchanged).
+			Msg("application commands synchronized")
+	}
 
 	return nil
 }
 
 // Unregister all discord application commands.
 func (b *Bot) UnregisterCommands() error {
-	// Getting all discord application commands.
-	commands, err := b.session.ApplicationCommands(b.session.State.User.ID, "")
+	for _, guild := range b.guilds {
+		// Getting all discord application commands in the guild.
+		commands, err := b.session.ApplicationCommands(b.session.State.User.ID, guild)
+		if err != nil {
+			return err
+		}
+
+		for _, command := range commands {
+			// Delete discord application commands.
+			if err := b.session.ApplicationCommandDelete(b.session.State.User.ID, guild, command.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Checking is sub command path exists in the discord application command options.
+func hasSubCommand(options []*discordgo.ApplicationCommandOption, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	for _, option := range options {
+		if option.Name != path[0] || !isSubCommand(option.Type) {
+			continue
+		}
+
+		// Checking is last sub command in the path.
+		if len(path) == 1 {
+			return option.Type == discordgo.ApplicationCommandOptionSubCommand
+		}
+
+		return hasSubCommand(option.Options, path[1:])
+	}
+
+	return false
+}
+
+// Checking is discord application command option type is sub command or sub
+// command group.
+func isSubCommand(t discordgo.ApplicationCommandOptionType) bool {
+	return t == discordgo.ApplicationCommandOptionSubCommand ||
+		t == discordgo.ApplicationCommandOptionSubCommandGroup
+}
+
+// Getting the difference between desired and current discord application commands.
+func diffCommands(desired, current []*discordgo.ApplicationCommand) commandsDiff {
+	var diff commandsDiff
+
+	existing := make(map[string]*discordgo.ApplicationCommand, len(current))
+
+	for _, command := range current {
+		existing[commandKey(command)] = command
+	}
+
+	for _, command := range desired {
+		key := commandKey(command)
+
+		// Checking is command exists.
+		if c, ok := existing[key]; !ok {
+			diff.Created = append(diff.Created, command.Name)
+		} else if !commandEqual(command, c) {
+			diff.Updated = append(diff.Updated, command.Name)
+		} else {
+			diff.Unchanged++
+		}
+
+		delete(existing, key)
+	}
+
+	for _, command := range current {
+		// Checking is command no longer desired.
+		if _, ok := existing[commandKey(command)]; ok {
+			diff.Deleted = append(diff.Deleted, command.Name)
+		}
+	}
+
+	return diff
+}
+
+// Getting a unique discord application command key.
+func commandKey(c *discordgo.ApplicationCommand) string {
+	return handlerKey(c.Type, c.Name)
+}
+
+// Getting a unique discord application command handler key by the command type
+// and path.
+func handlerKey(t discordgo.ApplicationCommandType, path string) string {
+	return fmt.Sprintf("%d:%s", commandType(t), path)
+}
+
+// Getting the type of the called discord application command. The interaction
+// data does not contain the command type, so it is determined by the target.
+func interactionCommandType(data discordgo.ApplicationCommandInteractionData) discordgo.ApplicationCommandType {
+	// Checking is context menu command.
+	if data.TargetID == "" {
+		return discordgo.ChatApplicationCommand
+	}
+
+	// Checking is target a message.
+	if data.Resolved != nil {
+		if _, ok := data.Resolved.Messages[data.TargetID]; ok {
+			return discordgo.MessageApplicationCommand
+		}
+	}
+
+	return discordgo.UserApplicationCommand
+}
+
+// Getting a discord application command type, the default is chat input.
+func commandType(t discordgo.ApplicationCommandType) discordgo.ApplicationCommandType {
+	if t == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+
+	return t
+}
+
+// Checking is discord application commands are equal.
+func commandEqual(a, b *discordgo.ApplicationCommand) bool {
+	x, err := json.Marshal(normalizeCommand(a))
 	if err != nil {
-		return err
+		return false
 	}
 
-	for _, command := range commands {
-		// Delete discord application commands.
-		if err := b.session.ApplicationCommandDelete(b.session.State.User.ID, "", command.ID); err != nil {
-			return err
+	y, err := json.Marshal(normalizeCommand(b))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(x, y)
+}
+
+// Normalizing discord application command, clearing fields set by discord and
+// replacing the empty values with their defaults.
+func normalizeCommand(c *discordgo.ApplicationCommand) discordgo.ApplicationCommand {
+	dmPermission := true
+	if c.DMPermission != nil {
+		dmPermission = *c.DMPermission
+	}
+
+	return discordgo.ApplicationCommand{
+		Type:                     commandType(c.Type),
+		Name:                     c.Name,
+		NameLocalizations:        normalizeLocalizations(c.NameLocalizations),
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+		DMPermission:             &dmPermission,
+		Description:              c.Description,
+		DescriptionLocalizations: normalizeLocalizations(c.DescriptionLocalizations),
+		Options:                  normalizeOptions(c.Options),
+	}
+}
+
+// Normalizing discord localizations.
+func normalizeLocalizations(l *map[discordgo.Locale]string) *map[discordgo.Locale]string {
+	if l == nil || len(*l) == 0 {
+		return nil
+	}
+
+	return l
+}
+
+// Normalizing discord application command options.
+func normalizeOptions(options []*discordgo.ApplicationCommandOption) []*discordgo.ApplicationCommandOption {
+	if len(options) == 0 {
+		return nil
+	}
+
+	normalized := make([]*discordgo.ApplicationCommandOption, len(options))
+
+	for i, option := range options {
+		o := *option
+
+		o.Options = normalizeOptions(o.Options)
+
+		if len(o.ChannelTypes) == 0 {
+			o.ChannelTypes = nil
 		}
+		if len(o.Choices) == 0 {
+			o.Choices = nil
+		}
+
+		normalized[i] = &o
 	}
 
-	return nil
+	return normalized
 }
@@ -0,0 +1,409 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test getting the difference between application commands.
+func TestBot_diffCommands(t *testing.T) {
+	// Testing args.
+	type args struct {
+		desired, current []*discordgo.ApplicationCommand
+	}
+
+	dmPermission := true
+
+	// Tests structures.
+	tests := []struct {
+		name string
+		args args
+		want commandsDiff
+	}{
+		{
+			name: "OK",
+			args: args{
+				desired: []*discordgo.ApplicationCommand{
+					{Name: "github", Description: "GitHub."},
+					{Name: "user", Description: "User."},
+					{Name: "epoch", Description: "New epoch."},
+				},
+				current: []*discordgo.ApplicationCommand{
+					{
+						ID:           "1",
+						Type:         discordgo.ChatApplicationCommand,
+						Name:         "github",
+						Description:  "GitHub.",
+						DMPermission: &dmPermission,
+					},
+					{ID: "2", Name: "epoch", Description: "Epoch."},
+					{ID: "3", Name: "old", Description: "Old."},
+				},
+			},
+			want: commandsDiff{
+				Created:   []string{"user"},
+				Updated:   []string{"epoch"},
+				Deleted:   []string{"old"},
+				Unchanged: 1,
+			},
+		},
+		{
+			name: "Unchanged Options",
+			args: args{
+				desired: []*discordgo.ApplicationCommand{
+					{
+						Name:        "use",
+						Description: "Use.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{Type: discordgo.ApplicationCommandOptionString, Name: "promo", Required: true},
+						},
+					},
+				},
+				current: []*discordgo.ApplicationCommand{
+					{
+						ID:          "1",
+						Name:        "use",
+						Description: "Use.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:         discordgo.ApplicationCommandOptionString,
+								Name:         "promo",
+								Required:     true,
+								ChannelTypes: []discordgo.ChannelType{},
+							},
+						},
+					},
+				},
+			},
+			want: commandsDiff{Unchanged: 1},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Getting the difference between application commands.
+			got := diffCommands(tt.args.desired, tt.args.current)
+
+			// Check for similarity of a difference.
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error difference are not similar: %+v", got)
+			}
+		})
+	}
+}
+
+// Test getting the application command path.
+func TestCommandPath(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name        string
+		data        discordgo.ApplicationCommandInteractionData
+		wantPath    string
+		wantOptions int
+	}{
+		{
+			name: "Command",
+			data: discordgo.ApplicationCommandInteractionData{
+				Name: "use",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Type: discordgo.ApplicationCommandOptionString, Name: "promo", Value: "durudex"},
+				},
+			},
+			wantPath:    "use",
+			wantOptions: 1,
+		},
+		{
+			name: "Sub Command Group",
+			data: discordgo.ApplicationCommandInteractionData{
+				Name: "admin",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+						Name: "balance",
+						Options: []*discordgo.ApplicationCommandInteractionDataOption{
+							{
+								Type: discordgo.ApplicationCommandOptionSubCommand,
+								Name: "set",
+								Options: []*discordgo.ApplicationCommandInteractionDataOption{
+									{Type: discordgo.ApplicationCommandOptionUser, Name: "user"},
+									{Type: discordgo.ApplicationCommandOptionInteger, Name: "amount"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantPath:    "admin balance set",
+			wantOptions: 2,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Getting the application command path.
+			path, options := CommandPath(tt.data)
+
+			// Check for similarity of a path.
+			if path != tt.wantPath || len(options) != tt.wantOptions {
+				t.Errorf("error path are not similar: %s (%d options)", path, len(options))
+			}
+		})
+	}
+}
+
+// Test registering application command with sub commands.
+func TestBot_RegisterCommand(t *testing.T) {
+	handler := func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {}
+
+	// Application command with sub commands.
+	command := discordgo.ApplicationCommand{
+		Name:        "promo",
+		Description: "Promo.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "create", Description: "Create."},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:        "balance",
+				Description: "Balance.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{Type: discordgo.ApplicationCommandOptionSubCommand, Name: "set", Description: "Set."},
+				},
+			},
+		},
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		sub     []string
+		wantErr bool
+	}{
+		{name: "OK", sub: []string{"create", "balance set"}},
+		{name: "Group", sub: []string{"balance"}, wantErr: true},
+		{name: "Unknown", sub: []string{"use"}, wantErr: true},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Bot{
+				commands: make(map[string]*Command),
+				handlers: make(map[string]HandlerFunc),
+			}
+
+			sub := make(map[string]HandlerFunc)
+			for _, path := range tt.sub {
+				sub[path] = handler
+			}
+
+			// Registering application command.
+			err := b.RegisterCommand(&Command{ApplicationCommand: command, SubCommands: sub})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error registering command: %v", err)
+			}
+
+			// Checking is handlers registered.
+			if err == nil && len(b.handlers) != len(tt.sub) {
+				t.Errorf("error handlers are not registered: %d", len(b.handlers))
+			}
+		})
+	}
+}
+
+// Test registering and routing context menu commands.
+func TestBot_ContextMenuCommand(t *testing.T) {
+	var called string
+
+	// Creating a new testing handler.
+	handler := func(name string) HandlerFunc {
+		return func(ctx context.Context, s Session, i *discordgo.InteractionCreate) { called = name }
+	}
+
+	// Creating a new testing context menu interaction.
+	interaction := func(resolved *discordgo.ApplicationCommandInteractionDataResolved) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{Name: "Profile", TargetID: "1", Resolved: resolved},
+		}}
+	}
+
+	b, err := New(&BotConfig{Token: "123"})
+	if err != nil {
+		t.Fatalf("error creating bot: %s", err.Error())
+	}
+
+	// Registering context menu commands with the same name.
+	for _, c := range []*Command{
+		{
+			ApplicationCommand: discordgo.ApplicationCommand{Type: discordgo.UserApplicationCommand, Name: "Profile"},
+			Handler:            handler("user"),
+		},
+		{
+			ApplicationCommand: discordgo.ApplicationCommand{Type: discordgo.MessageApplicationCommand, Name: "Profile"},
+			Handler:            handler("message"),
+		},
+	} {
+		if err := b.RegisterCommand(c); err != nil {
+			t.Fatalf("error registering command: %s", err.Error())
+		}
+	}
+
+	// Registering context menu command with a description.
+	if err := b.RegisterCommand(&Command{
+		ApplicationCommand: discordgo.ApplicationCommand{
+			Type:        discordgo.UserApplicationCommand,
+			Name:        "Balance",
+			Description: "Balance.",
+		},
+	}); err == nil {
+		t.Error("error expected context menu command description error")
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		interaction *discordgo.InteractionCreate
+		want        string
+	}{
+		{
+			name: "User",
+			interaction: interaction(&discordgo.ApplicationCommandInteractionDataResolved{
+				Users: map[string]*discordgo.User{"1": {ID: "1"}},
+			}),
+			want: "user",
+		},
+		{
+			name: "Message",
+			interaction: interaction(&discordgo.ApplicationCommandInteractionDataResolved{
+				Messages: map[string]*discordgo.Message{"1": {ID: "1"}},
+			}),
+			want: "message",
+		},
+		{name: "Without Resolved", interaction: interaction(nil), want: "user"},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = ""
+
+			// Handling the interaction.
+			b.Handle(nil, tt.interaction)
+
+			if called != tt.want {
+				t.Errorf("error handler are not similar: %s", called)
+			}
+			if tt.interaction.ApplicationCommandData().Resolved == nil &&
+				(TargetUser(tt.interaction) != nil || TargetMessage(tt.interaction) != nil) {
+				t.Error("error target is resolved without resolved data")
+			}
+		})
+	}
+}
+
+// Discord API transport, it records requests and responds with no commands.
+type apiTransport struct{ requests []string }
+
+// Recording the discord API request.
+func (t *apiTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	_, path, _ := strings.Cut(r.URL.Path, "/applications/1")
+	t.requests = append(t.requests, r.Method+" "+path)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("[]")),
+		Request:    r,
+	}, nil
+}
+
+// Test synchronizing application commands per guild.
+func TestBot_SyncCommands(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name         string
+		cfg          BotConfig
+		wantRequests []string
+	}{
+		{
+			name: "Guilds",
+			cfg:  BotConfig{Guilds: []string{"10", "20"}},
+			wantRequests: []string{
+				"GET /guilds/10/commands", "PUT /guilds/10/commands",
+				"GET /guilds/20/commands", "PUT /guilds/20/commands",
+			},
+		},
+		{
+			name:         "Global",
+			cfg:          BotConfig{},
+			wantRequests: []string{"GET /commands", "PUT /commands"},
+		},
+		{
+			name: "Guilds And Global",
+			cfg:  BotConfig{Guilds: []string{"10"}, Global: true},
+			wantRequests: []string{
+				"GET /guilds/10/commands", "PUT /guilds/10/commands",
+				"GET /commands", "PUT /commands",
+			},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Token = "123"
+
+			b, err := New(&tt.cfg)
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			transport := &apiTransport{}
+
+			b.session.Client = &http.Client{Transport: transport}
+			b.session.State.User = &discordgo.User{ID: "1"}
+
+			// Registering a testing application command.
+			if err := b.RegisterCommand(&Command{
+				ApplicationCommand: discordgo.ApplicationCommand{Name: "ping", Description: "Ping."},
+			}); err != nil {
+				t.Fatalf("error registering command: %s", err.Error())
+			}
+
+			// Synchronizing application commands.
+			if err := b.SyncCommands(); err != nil {
+				t.Fatalf("error synchronizing commands: %s", err.Error())
+			}
+
+			// Check for similarity of a requests.
+			if !reflect.DeepEqual(transport.requests, tt.wantRequests) {
+				t.Errorf("error requests are not similar: %v", transport.requests)
+			}
+		})
+	}
+}
@@ -17,17 +17,26 @@
 
 package bot
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+)
 
 // Discord message component structure.
 type Component struct {
-	// Custom component id.
+	// Custom component id or pattern with parameters, for example
+	// "review:approve:{user}".
 	ComponentID string
-	// Discord message component handler.
-	Handler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	// Discord message component handler, the params are parsed from the custom
+	// id by the component id pattern.
+	Handler func(ctx context.Context, s Session, i *discordgo.InteractionCreate, params Params)
+	// Discord message component middlewares, they are applied after the global
+	// middlewares.
+	Middlewares []Middleware
 }
 
 // Registering a new discord message component.
 func (b *Bot) RegisterComponent(c *Component) {
-	b.components[c.ComponentID] = c
+	b.components.Add(c.ComponentID, c)
 }
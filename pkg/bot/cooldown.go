@@ -0,0 +1,201 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+// Interval after which expired cooldowns are removed.
+const cooldownSweepInterval time.Duration = time.Minute
+
+// Discord application command cooldown structure. A zero duration disables the
+// cooldown.
+type Cooldown struct {
+	// Cooldown of the command per user.
+	User time.Duration
+	// Cooldown of the command per guild.
+	Guild time.Duration
+	// Cooldown of the command for all users.
+	Command time.Duration
+}
+
+// Discord application commands cooldowns config structure.
+type CooldownConfig struct {
+	// Application commands cooldowns by command path.
+	Commands map[string]Cooldown
+	// Getting roles of members who are not limited by cooldowns, it is called
+	// on each check so the roles can be reloaded.
+	BypassRoles func() []string
+	// Replying to the interaction when the cooldown is active. If the function
+	// is not specified, the default message is sent.
+	Reply func(r *Responder, retryAfter time.Duration)
+}
+
+// Discord application commands cooldowns structure.
+type cooldowns struct {
+	// Cooldowns config.
+	cfg *CooldownConfig
+	// Cooldowns mutex.
+	mutex sync.Mutex
+	// Cooldowns expiration time by key.
+	expires map[string]time.Time
+	// Last time when expired cooldowns were removed.
+	swept time.Time
+}
+
+// Creating a new discord application commands cooldowns.
+func newCooldowns(cfg *CooldownConfig) *cooldowns {
+	return &cooldowns{cfg: cfg, expires: make(map[string]time.Time)}
+}
+
+// Reserving the command cooldowns, they are checked and started at once so
+// concurrent interactions can't pass the check together. The time after which
+// the command can be used is returned if a cooldown is active, the cooldowns
+// are not started in this case.
+func (c *cooldowns) reserve(path string, i *discordgo.InteractionCreate, now time.Time) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Removing expired cooldowns.
+	if now.Sub(c.swept) > cooldownSweepInterval {
+		c.sweep(now)
+	}
+
+	durations := c.durations(path, i)
+
+	var retryAfter time.Duration
+
+	// Getting the longest active cooldown.
+	for key := range durations {
+		if remaining := c.expires[key].Sub(now); remaining > retryAfter {
+			retryAfter = remaining
+		}
+	}
+
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	// Starting the command cooldowns.
+	for key, duration := range durations {
+		c.expires[key] = now.Add(duration)
+	}
+
+	return 0
+}
+
+// Getting the command cooldowns durations by key, members with a bypass role
+// have no cooldowns.
+func (c *cooldowns) durations(path string, i *discordgo.InteractionCreate) map[string]time.Duration {
+	cooldown, ok := c.cfg.Commands[path]
+	if !ok || c.bypass(i) {
+		return nil
+	}
+
+	durations := make(map[string]time.Duration, 3)
+
+	if cooldown.User > 0 {
+		durations["user:"+path+":"+Author(i).ID] = cooldown.User
+	}
+	if cooldown.Guild > 0 && i.GuildID != "" {
+		durations["guild:"+path+":"+i.GuildID] = cooldown.Guild
+	}
+	if cooldown.Command > 0 {
+		durations["command:"+path] = cooldown.Command
+	}
+
+	return durations
+}
+
+// Checking is the interaction member has a bypass role.
+func (c *cooldowns) bypass(i *discordgo.InteractionCreate) bool {
+	if i.Member == nil || c.cfg.BypassRoles == nil {
+		return false
+	}
+
+	roles := c.cfg.BypassRoles()
+
+	for _, role := range i.Member.Roles {
+		if contains(roles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Removing expired cooldowns.
+func (c *cooldowns) sweep(now time.Time) {
+	for key, expires := range c.expires {
+		if !expires.After(now) {
+			delete(c.expires, key)
+		}
+	}
+
+	c.swept = now
+}
+
+// Replying to the interaction when the cooldown is active.
+func (c *cooldowns) reply(r *Responder, retryAfter time.Duration) {
+	if c.cfg.Reply != nil {
+		c.cfg.Reply(r, retryAfter)
+		return
+	}
+
+	// Send a interaction respond message.
+	if err := r.Respond(&discordgo.InteractionResponseData{
+		Content: fmt.Sprintf("Try again in %d seconds.", CooldownSeconds(retryAfter)),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}); err != nil {
+		log.Warn().Err(err).Msg("failed to send interaction respond message")
+	}
+}
+
+// Getting the number of whole seconds of the cooldown, it is rounded up.
+func CooldownSeconds(retryAfter time.Duration) int {
+	return int(math.Ceil(retryAfter.Seconds()))
+}
+
+// Wrapping the application command handler, the handler is not called if the
+// command cooldown is active. The cooldowns are reserved before the handler and
+// kept even if the handler fails, so promo codes can't be brute forced. It must
+// be wrapped by the command middlewares, so interactions rejected by them do
+// not reserve the cooldowns.
+func (b *Bot) cooldown(path string, next HandlerFunc) HandlerFunc {
+	if b.cooldowns == nil {
+		return next
+	}
+
+	return func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+		// Reserving the command cooldowns.
+		if retryAfter := b.cooldowns.reserve(path, i, time.Now()); retryAfter > 0 {
+			b.cooldowns.reply(b.NewResponder(s, i), retryAfter)
+			return
+		}
+
+		next(ctx, s, i)
+	}
+}
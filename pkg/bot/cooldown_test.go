@@ -0,0 +1,137 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test reserving the application command cooldowns.
+func TestCooldowns_Reserve(t *testing.T) {
+	now := time.Unix(1660000000, 0)
+
+	// Creating a new testing interaction.
+	interaction := func(user, guild string, roles ...string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			GuildID: guild,
+			Member:  &discordgo.Member{User: &discordgo.User{ID: user}, Roles: roles},
+		}}
+	}
+
+	// Testing call.
+	type call struct {
+		path        string
+		interaction *discordgo.InteractionCreate
+		after       time.Duration
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name  string
+		calls []call
+		want  time.Duration
+	}{
+		{
+			name: "User",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1")},
+				{path: "use", interaction: interaction("1", "2"), after: time.Second * 10},
+			},
+			want: time.Second * 20,
+		},
+		{
+			name: "User Not Extended",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1")},
+				{path: "use", interaction: interaction("1", "1"), after: time.Second * 10},
+				{path: "use", interaction: interaction("1", "1"), after: time.Second * 20},
+			},
+			want: time.Second * 10,
+		},
+		{
+			name: "User Expired",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1")},
+				{path: "use", interaction: interaction("1", "1"), after: time.Second * 30},
+			},
+		},
+		{
+			name: "Other User",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1")},
+				{path: "use", interaction: interaction("2", "2")},
+			},
+		},
+		{
+			name: "Guild",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1")},
+				{path: "use", interaction: interaction("2", "1"), after: time.Second * 2},
+			},
+			want: time.Second * 3,
+		},
+		{
+			name: "Command",
+			calls: []call{
+				{path: "create", interaction: interaction("1", "1")},
+				{path: "create", interaction: interaction("2", "2"), after: time.Second},
+			},
+			want: time.Second * 59,
+		},
+		{
+			name: "Other Command",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1")},
+				{path: "register", interaction: interaction("1", "1")},
+			},
+		},
+		{
+			name: "Bypass",
+			calls: []call{
+				{path: "use", interaction: interaction("1", "1", "review")},
+				{path: "use", interaction: interaction("1", "1", "review")},
+			},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCooldowns(&CooldownConfig{
+				Commands: map[string]Cooldown{
+					"use":    {User: time.Second * 30, Guild: time.Second * 5},
+					"create": {Command: time.Minute},
+				},
+				BypassRoles: func() []string { return []string{"review"} },
+			})
+
+			var got time.Duration
+
+			for _, call := range tt.calls {
+				got = c.reserve(call.path, call.interaction, now.Add(call.after))
+			}
+
+			if got != tt.want {
+				t.Errorf("error retry after are not similar: %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Max interactions endpoint request body size.
+	maxInteractionSize int64 = 1 << 20
+	// Max difference between the request signature timestamp and the current
+	// time, older requests are rejected as replayed.
+	maxTimestampSkew time.Duration = time.Minute * 5
+	// Time discord waits for the initial interaction response, after that
+	// the interaction response is deferred.
+	initialResponseTimeout time.Duration = time.Second * 3
+)
+
+// Error of responding to the interaction which response has been deferred by
+// the interactions endpoint, the original response must be edited instead.
+var errInteractionDeferred = errors.New("interaction response already deferred")
+
+// Interactions endpoint session, the initial interaction response is written
+// to the HTTP response body and other operations are sent through the session
+// webhook endpoints.
+type httpSession struct {
+	Session
+	// Initial interaction response, it is received by the HTTP handler.
+	response chan *discordgo.InteractionResponse
+	// Initial response written channel, it is closed when the HTTP response
+	// is written.
+	written chan struct{}
+	// Error of writing the initial response.
+	err error
+	// Initial response deferred by the interactions endpoint status.
+	deferred bool
+}
+
+// Creating a new interactions endpoint session.
+func newHTTPSession(s Session) *httpSession {
+	return &httpSession{
+		Session:  s,
+		response: make(chan *discordgo.InteractionResponse),
+		written:  make(chan struct{}),
+	}
+}
+
+// Responding to the discord interaction. The initial response is written to
+// the HTTP response, after that responses are sent through the session. If the
+// response has been deferred by the interactions endpoint, an error is
+// returned, so the responder edits the original response.
+func (s *httpSession) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse) error {
+	select {
+	case s.response <- resp:
+		<-s.written
+		return s.err
+	case <-s.written:
+		// Checking is interaction response deferred by the endpoint.
+		if s.deferred {
+			return errInteractionDeferred
+		}
+
+		return s.Session.InteractionRespond(interaction, resp)
+	}
+}
+
+// Running the discord bot in the interactions endpoint mode. Interactions are
+// received by the HTTP server instead of the gateway session and are routed
+// through the same handlers.
+func (b *Bot) RunHTTP(addr string, publicKey ed25519.PublicKey) error {
+	// Checking application public key size.
+	if len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid application public key size")
+	}
+
+	// Getting the bot user, the gateway ready state is not received in the
+	// interactions endpoint mode.
+	user, err := b.session.User("@me")
+	if err != nil {
+		return err
+	}
+
+	b.session.State.User = user
+
+	// Checking is defer threshold less than the endpoint response timeout,
+	// so the responder defers the response before the endpoint.
+	if b.deferThreshold >= b.responseTimeout {
+		b.deferThreshold = DefaultDeferThreshold
+	}
+
+	b.server = &http.Server{Addr: addr, Handler: b.InteractionsHandler(publicKey)}
+
+	go func() {
+		log.Info().Str("addr", addr).Msg("interactions endpoint started")
+
+		// Starting the interactions endpoint server.
+		if err := b.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("failed to serve interactions endpoint")
+		}
+	}()
+
+	return nil
+}
+
+// Closing the interactions endpoint server, it waits for the interactions in
+// handling to finish.
+func (b *Bot) closeHTTP() error {
+	if b.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), InteractionTimeout)
+	defer cancel()
+
+	return b.server.Shutdown(ctx)
+}
+
+// Creating a new discord interactions endpoint handler. The request signature
+// is verified with the application public key and the interaction is handled
+// in the background. The initial interaction response is written to the HTTP
+// response, if the handler does not respond in time, the response is deferred.
+func (b *Bot) InteractionsHandler(publicKey ed25519.PublicKey) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Checking request method.
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Reading the limited request body.
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxInteractionSize))
+		if err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Verifying the request timestamp and signature.
+		if !validTimestamp(r.Header.Get("X-Signature-Timestamp")) || !discordgo.VerifyInteraction(r, publicKey) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var interaction discordgo.Interaction
+
+		// Decoding the interaction.
+		if err := json.Unmarshal(body, &interaction); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// Checking is ping interaction, it is sent by discord to check the
+		// endpoint.
+		if interaction.Type == discordgo.InteractionPing {
+			writeResponse(w, &discordgo.InteractionResponse{Type: discordgo.InteractionResponsePong})
+			return
+		}
+
+		s := newHTTPSession(b.session)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			// Handle discord interaction.
+			b.Handle(s, &discordgo.InteractionCreate{Interaction: &interaction})
+		}()
+
+		timer := time.NewTimer(b.responseTimeout)
+		defer timer.Stop()
+
+		// Waiting for the initial interaction response.
+		select {
+		case resp := <-s.response:
+			s.err = writeResponse(w, resp)
+		case <-done:
+			// The interaction is handled without a response.
+			w.WriteHeader(http.StatusAccepted)
+		case <-timer.C:
+			// Checking is autocomplete interaction, it can't be deferred so
+			// no choices are sent.
+			if interaction.Type == discordgo.InteractionApplicationCommandAutocomplete {
+				log.Warn().Str("interaction", interaction.ID).Msg("autocomplete response timeout")
+
+				s.err = writeResponse(w, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+					Data: &discordgo.InteractionResponseData{},
+				})
+				break
+			}
+
+			log.Warn().Str("interaction", interaction.ID).Msg("interaction response timeout, deferring")
+
+			s.err = writeResponse(w, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			})
+			s.deferred = s.err == nil
+		}
+
+		close(s.written)
+	})
+}
+
+// Writing the interaction response to the HTTP response body.
+func writeResponse(w http.ResponseWriter, resp *discordgo.InteractionResponse) error {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Warn().Err(err).Msg("failed to write interaction response")
+		return err
+	}
+
+	return nil
+}
+
+// Checking is request signature timestamp is within the allowed skew.
+func validTimestamp(value string) bool {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	skew := time.Since(time.Unix(seconds, 0))
+
+	return skew < maxTimestampSkew && skew > -maxTimestampSkew
+}
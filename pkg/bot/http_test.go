@@ -0,0 +1,293 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test handling interactions endpoint requests.
+func TestBot_InteractionsHandler(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err.Error())
+	}
+
+	b, err := New(&BotConfig{Token: "123"})
+	if err != nil {
+		t.Fatalf("error creating bot: %s", err.Error())
+	}
+
+	var handled bool
+
+	// Registering testing application commands.
+	for _, c := range []*Command{
+		{
+			ApplicationCommand: discordgo.ApplicationCommand{Name: "ping", Description: "Ping."},
+			Handler: func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+				handled = true
+			},
+		},
+		{
+			ApplicationCommand: discordgo.ApplicationCommand{Name: "pong", Description: "Pong."},
+			Handler: func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+				handled = true
+
+				// Send a interaction respond message.
+				if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{Content: "Pong"},
+				}); err != nil {
+					t.Errorf("error sending response: %s", err.Error())
+				}
+			},
+		},
+	} {
+		if err := b.RegisterCommand(c); err != nil {
+			t.Fatalf("error registering command: %s", err.Error())
+		}
+	}
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// Creating a new signed request.
+	request := func(method, body, timestamp string, key ed25519.PrivateKey) *http.Request {
+		r := httptest.NewRequest(method, "/", strings.NewReader(body))
+
+		signature := ed25519.Sign(key, []byte(timestamp+body))
+
+		r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+		r.Header.Set("X-Signature-Timestamp", timestamp)
+
+		return r
+	}
+
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err.Error())
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		request     *http.Request
+		wantStatus  int
+		wantBody    string
+		wantHandled bool
+	}{
+		{
+			name:       "Ping",
+			request:    request(http.MethodPost, `{"type":1}`, now, privateKey),
+			wantStatus: http.StatusOK,
+			wantBody:   `{"type":1}`,
+		},
+		{
+			name:        "Command",
+			request:     request(http.MethodPost, `{"type":2,"data":{"name":"ping"}}`, now, privateKey),
+			wantStatus:  http.StatusAccepted,
+			wantHandled: true,
+		},
+		{
+			name:        "Command Response",
+			request:     request(http.MethodPost, `{"type":2,"data":{"name":"pong"}}`, now, privateKey),
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"type":4,"data":{"tts":false,"content":"Pong","components":null,"embeds":null}}`,
+			wantHandled: true,
+		},
+		{
+			name:       "Invalid Signature",
+			request:    request(http.MethodPost, `{"type":1}`, now, otherKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Stale Timestamp",
+			request:    request(http.MethodPost, `{"type":1}`, "1660000000", privateKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Too Large",
+			request:    request(http.MethodPost, strings.Repeat(" ", 1<<20+1), now, privateKey),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "Invalid Body",
+			request:    request(http.MethodPost, `{`, now, privateKey),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Method",
+			request:    request(http.MethodGet, "", now, privateKey),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = false
+
+			w := httptest.NewRecorder()
+
+			// Handling the interactions endpoint request.
+			b.InteractionsHandler(publicKey).ServeHTTP(w, tt.request)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("error status are not similar: %d", w.Code)
+			}
+			if body := string(bytes.TrimSpace(w.Body.Bytes())); body != tt.wantBody {
+				t.Errorf("error body are not similar: %s", body)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("error handled are not similar: %t", handled)
+			}
+		})
+	}
+}
+
+// Discord API transport, it sends the requests to the channel.
+type requestsTransport chan string
+
+// Sending the discord API request to the channel.
+func (t requestsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t <- r.Method + " " + r.URL.Path
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    r,
+	}, nil
+}
+
+// Test handling interactions endpoint requests after the response timeout.
+func TestBot_InteractionsHandlerTimeout(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err.Error())
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		body        string
+		wantBody    string
+		wantRequest string
+	}{
+		{
+			name:        "Command",
+			body:        `{"id":"2","application_id":"1","token":"token","type":2,"data":{"name":"slow"}}`,
+			wantBody:    `{"type":5}`,
+			wantRequest: "PATCH /api/v9/webhooks/1/token/messages/@original",
+		},
+		{
+			name:        "Autocomplete",
+			body:        `{"id":"2","application_id":"1","token":"token","type":4,"data":{"name":"slow","options":[{"type":3,"name":"query","value":"a","focused":true}]}}`,
+			wantBody:    `{"type":8,"data":{"tts":false,"content":"","components":null,"embeds":null}}`,
+			wantRequest: "POST /api/v9/interactions/2/token/callback",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := New(&BotConfig{Token: "123"})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			b.responseTimeout = time.Millisecond * 10
+
+			requests := make(requestsTransport, 1)
+			b.session.Client = &http.Client{Transport: requests}
+
+			release := make(chan struct{})
+
+			// Registering a testing application command, it responds after
+			// the response timeout.
+			if err := b.RegisterCommand(&Command{
+				ApplicationCommand: discordgo.ApplicationCommand{
+					Name:        "slow",
+					Description: "Slow.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{Type: discordgo.ApplicationCommandOptionString, Name: "query", Description: "Query."},
+					},
+				},
+				Handler: func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+					<-release
+
+					// Send a interaction respond message.
+					if err := b.NewResponder(s, i).Respond(&discordgo.InteractionResponseData{Content: "Slow"}); err != nil {
+						t.Errorf("error sending response: %s", err.Error())
+					}
+				},
+				Autocomplete: map[string]AutocompleteHandler{
+					"query": func(
+						ctx context.Context,
+						s Session,
+						i *discordgo.InteractionCreate,
+						option *discordgo.ApplicationCommandInteractionDataOption,
+					) []*discordgo.ApplicationCommandOptionChoice {
+						<-release
+						return nil
+					},
+				},
+			}); err != nil {
+				t.Fatalf("error registering command: %s", err.Error())
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+			r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+tt.body))))
+			r.Header.Set("X-Signature-Timestamp", timestamp)
+
+			w := httptest.NewRecorder()
+
+			// Handling the interactions endpoint request.
+			b.InteractionsHandler(publicKey).ServeHTTP(w, r)
+
+			if body := string(bytes.TrimSpace(w.Body.Bytes())); body != tt.wantBody {
+				t.Errorf("error body are not similar: %s", body)
+			}
+
+			close(release)
+
+			// Waiting for the late interaction response.
+			select {
+			case request := <-requests:
+				if request != tt.wantRequest {
+					t.Errorf("error request are not similar: %s", request)
+				}
+			case <-time.After(time.Second):
+				t.Error("error late response is not sent")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord interaction handler. The context is canceled when the interaction
+// handling timeout expires or the bot is closed.
+type HandlerFunc func(ctx context.Context, s Session, i *discordgo.InteractionCreate)
+
+// Discord interaction handler middleware. The middleware can stop handling the
+// interaction by not calling the next handler.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Registering global middlewares for all commands, components and modals.
+func (b *Bot) Use(middlewares ...Middleware) {
+	b.middlewares = append(b.middlewares, middlewares...)
+}
+
+// Wrapping the handler in middlewares, the first middleware is the outermost.
+func Chain(handler HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// Getting the user who created the interaction in a guild or in dm.
+func Author(i *discordgo.InteractionCreate) *discordgo.User {
+	// Checking where the interaction was created.
+	if i.Interaction.User == nil {
+		return i.Interaction.Member.User
+	}
+
+	return i.Interaction.User
+}
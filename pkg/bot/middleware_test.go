@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test wrapping the handler in middlewares.
+func TestChain(t *testing.T) {
+	var calls []string
+
+	// Creating a new testing middleware.
+	middleware := func(name string, next bool) Middleware {
+		return func(h HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+				calls = append(calls, name)
+
+				if next {
+					h(ctx, s, i)
+				}
+			}
+		}
+	}
+
+	handler := func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {
+		calls = append(calls, "handler")
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name        string
+		middlewares []Middleware
+		want        []string
+	}{
+		{name: "Empty", want: []string{"handler"}},
+		{
+			name:        "Order",
+			middlewares: []Middleware{middleware("first", true), middleware("second", true)},
+			want:        []string{"first", "second", "handler"},
+		},
+		{
+			name:        "Stop",
+			middlewares: []Middleware{middleware("first", false), middleware("second", true)},
+			want:        []string{"first"},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+
+			// Calling the wrapped handler.
+			Chain(handler, tt.middlewares...)(context.Background(), nil, &discordgo.InteractionCreate{})
+
+			// Check for similarity of a calls.
+			if !reflect.DeepEqual(calls, tt.want) {
+				t.Errorf("error calls are not similar: %v", calls)
+			}
+		})
+	}
+}
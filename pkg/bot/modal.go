@@ -0,0 +1,137 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord modal structure.
+type Modal struct {
+	// Custom modal id or pattern with parameters, for example
+	// "balance:{user}".
+	ModalID string
+	// Modal title.
+	Title string
+	// Modal text inputs, each input is placed in a separate row.
+	Inputs []discordgo.TextInput
+	// Localizing the modal title and text inputs labels for the interaction
+	// locale when the modal is opened. If the function is not specified, the
+	// modal is not localized.
+	Localize func(locale discordgo.Locale, text string) string
+	// Discord modal submit handler, the params are parsed from the custom id
+	// by the modal id pattern.
+	Handler func(
+		ctx context.Context,
+		s Session,
+		i *discordgo.InteractionCreate,
+		params Params,
+		fields ModalFields,
+	)
+	// Discord modal middlewares, they are applied after the global middlewares.
+	Middlewares []Middleware
+}
+
+// Discord modal submitted text inputs values by custom id.
+type ModalFields map[string]string
+
+// Getting a submitted text input value.
+func (f ModalFields) Get(id string) string {
+	return f[id]
+}
+
+// Registering a new discord modal.
+func (b *Bot) RegisterModal(m *Modal) {
+	b.modals.Add(m.ModalID, m)
+}
+
+// Responding to the interaction by opening a registered discord modal. The
+// custom id must match the registered modal id pattern, the interaction
+// response must not be deferred.
+func (b *Bot) OpenModal(s Session, i *discordgo.InteractionCreate, id string) error {
+	m, _, ok := b.modals.Match(id)
+	if !ok {
+		return fmt.Errorf("modal %s is not registered", id)
+	}
+
+	return b.NewResponder(s, i).openModal(m.Response(id, i.Locale))
+}
+
+// Getting a discord interaction response that opens the modal with the custom
+// id, the modal is localized for the locale.
+func (m *Modal) Response(id string, locale discordgo.Locale) *discordgo.InteractionResponse {
+	title := m.Title
+	components := make([]discordgo.MessageComponent, len(m.Inputs))
+
+	// Checking is modal localized.
+	if m.Localize != nil {
+		title = m.Localize(locale, title)
+	}
+
+	for i, input := range m.Inputs {
+		// Localizing the text input label.
+		if m.Localize != nil {
+			input.Label = m.Localize(locale, input.Label)
+		}
+
+		components[i] = discordgo.ActionsRow{Components: []discordgo.MessageComponent{input}}
+	}
+
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID:   id,
+			Title:      title,
+			Components: components,
+		},
+	}
+}
+
+// Getting submitted text inputs values from discord modal submit data.
+func modalFields(data discordgo.ModalSubmitInteractionData) ModalFields {
+	fields := make(ModalFields)
+
+	for _, component := range data.Components {
+		var row discordgo.ActionsRow
+
+		// Checking the row component type.
+		switch c := component.(type) {
+		case *discordgo.ActionsRow:
+			row = *c
+		case discordgo.ActionsRow:
+			row = c
+		default:
+			continue
+		}
+
+		for _, component := range row.Components {
+			// Checking the text input component type.
+			switch c := component.(type) {
+			case *discordgo.TextInput:
+				fields[c.CustomID] = c.Value
+			case discordgo.TextInput:
+				fields[c.CustomID] = c.Value
+			}
+		}
+	}
+
+	return fields
+}
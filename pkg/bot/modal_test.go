@@ -0,0 +1,124 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+package bot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test getting submitted text inputs values.
+func TestModalFields(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name       string
+		components []discordgo.MessageComponent
+		want       ModalFields
+	}{
+		{name: "Empty", want: ModalFields{}},
+		{
+			name: "Rows",
+			components: []discordgo.MessageComponent{
+				&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+					&discordgo.TextInput{CustomID: "promo", Value: "durudex"},
+				}},
+				discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+					discordgo.TextInput{CustomID: "reason", Value: "Event"},
+				}},
+			},
+			want: ModalFields{"promo": "durudex", "reason": "Event"},
+		},
+		{
+			name: "Other Components",
+			components: []discordgo.MessageComponent{
+				&discordgo.Button{CustomID: "button"},
+				&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+					&discordgo.Button{CustomID: "button"},
+					&discordgo.TextInput{CustomID: "promo"},
+				}},
+			},
+			want: ModalFields{"promo": ""},
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Getting submitted text inputs values.
+			got := modalFields(discordgo.ModalSubmitInteractionData{Components: tt.components})
+
+			// Check for similarity of a fields.
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error fields are not similar: %v", got)
+			}
+		})
+	}
+}
+
+// Test getting the discord interaction response that opens the modal.
+func TestModal_Response(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name      string
+		localize  func(locale discordgo.Locale, text string) string
+		wantTitle string
+		wantLabel string
+	}{
+		{
+			name:      "OK",
+			wantTitle: "title",
+			wantLabel: "label",
+		},
+		{
+			name: "Localized",
+			localize: func(locale discordgo.Locale, text string) string {
+				return string(locale) + " " + text
+			},
+			wantTitle: "uk title",
+			wantLabel: "uk label",
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modal{
+				ModalID:  "modal",
+				Title:    "title",
+				Inputs:   []discordgo.TextInput{{CustomID: "input", Label: "label"}},
+				Localize: tt.localize,
+			}
+
+			data := m.Response("modal", discordgo.Ukrainian).Data
+
+			if data.Title != tt.wantTitle {
+				t.Errorf("error title are not similar: %s", data.Title)
+			}
+
+			input := data.Components[0].(discordgo.ActionsRow).Components[0].(discordgo.TextInput)
+
+			if input.Label != tt.wantLabel {
+				t.Errorf("error label are not similar: %s", input.Label)
+			}
+			if m.Inputs[0].Label != "label" {
+				t.Errorf("error modal input is localized: %s", m.Inputs[0].Label)
+			}
+		})
+	}
+}
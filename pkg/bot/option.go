@@ -0,0 +1,304 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord application command option struct tags.
+const (
+	// Option tag, the first value is the option name and the rest are flags:
+	// "required", "autocomplete", "min=<number>", "max=<number>",
+	// "minlen=<number>" and "maxlen=<number>".
+	optionTag string = "option"
+	// Option description tag.
+	descriptionTag string = "description"
+)
+
+// Discord application command option types by field type.
+var optionTypes = map[reflect.Type]discordgo.ApplicationCommandOptionType{
+	reflect.TypeOf(""):                   discordgo.ApplicationCommandOptionString,
+	reflect.TypeOf(0):                    discordgo.ApplicationCommandOptionInteger,
+	reflect.TypeOf(int64(0)):             discordgo.ApplicationCommandOptionInteger,
+	reflect.TypeOf(float64(0)):           discordgo.ApplicationCommandOptionNumber,
+	reflect.TypeOf(false):                discordgo.ApplicationCommandOptionBoolean,
+	reflect.TypeOf(&discordgo.User{}):    discordgo.ApplicationCommandOptionUser,
+	reflect.TypeOf(&discordgo.Channel{}): discordgo.ApplicationCommandOptionChannel,
+	reflect.TypeOf(&discordgo.Role{}):    discordgo.ApplicationCommandOptionRole,
+}
+
+// Discord application command option field structure.
+type optionField struct {
+	// Struct field index.
+	index int
+	// Discord application command option.
+	option discordgo.ApplicationCommandOption
+}
+
+// Getting discord application command options from the struct fields tags,
+// required options must be defined before optional options. Options are
+// defined as follows:
+//
+//	type options struct {
+//		User   *discordgo.User `option:"user,required" description:"Target user."`
+//		Amount int             `option:"amount,required,min=1" description:"Amount."`
+//	}
+func Options(v any) ([]*discordgo.ApplicationCommandOption, error) {
+	fields, err := optionFields(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]*discordgo.ApplicationCommandOption, len(fields))
+
+	for i := range fields {
+		// Checking is required option defined after an optional option,
+		// discord rejects such command definitions.
+		if i > 0 && fields[i].option.Required && !fields[i-1].option.Required {
+			return nil, fmt.Errorf("required option %s follows an optional option", fields[i].option.Name)
+		}
+
+		options[i] = &fields[i].option
+	}
+
+	return options, nil
+}
+
+// Getting discord application command options from the struct fields tags,
+// it panics if the options are invalid.
+func MustOptions(v any) []*discordgo.ApplicationCommandOption {
+	options, err := Options(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return options
+}
+
+// Binding discord application command options of the interaction by name to
+// the struct pointed to by dst. For sub commands the options of the last sub
+// command in the path are bound.
+func BindOptions(i *discordgo.InteractionCreate, dst any) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("options destination must be a pointer to a struct, got %T", dst)
+	}
+
+	fields, err := optionFields(value.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	data := i.ApplicationCommandData()
+	_, options := CommandPath(data)
+
+	values := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, option := range options {
+		values[option.Name] = option
+	}
+
+	for _, field := range fields {
+		option, ok := values[field.option.Name]
+		if !ok {
+			// Checking is option required.
+			if field.option.Required {
+				return fmt.Errorf("option %s is required", field.option.Name)
+			}
+
+			continue
+		}
+
+		if err := bindOption(value.Elem().Field(field.index), field.option, option, data.Resolved); err != nil {
+			return fmt.Errorf("option %s: %w", field.option.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Binding discord application command option value to the struct field.
+func bindOption(
+	field reflect.Value,
+	def discordgo.ApplicationCommandOption,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+) error {
+	if option.Type != def.Type {
+		return fmt.Errorf("expected type %s, got %s", def.Type, option.Type)
+	}
+
+	switch def.Type {
+	case discordgo.ApplicationCommandOptionString:
+		value := option.StringValue()
+		length := utf8.RuneCountInString(value)
+
+		// Checking string length, discord counts the length in characters.
+		if def.MinLength != nil && length < *def.MinLength {
+			return fmt.Errorf("length is less than %d", *def.MinLength)
+		} else if def.MaxLength != 0 && length > def.MaxLength {
+			return fmt.Errorf("length is greater than %d", def.MaxLength)
+		}
+
+		field.SetString(value)
+	case discordgo.ApplicationCommandOptionInteger, discordgo.ApplicationCommandOptionNumber:
+		value, ok := option.Value.(float64)
+		if !ok {
+			return fmt.Errorf("value is not a number")
+		}
+
+		// Checking number range.
+		if def.MinValue != nil && value < *def.MinValue {
+			return fmt.Errorf("value is less than %v", *def.MinValue)
+		} else if def.MaxValue != 0 && value > def.MaxValue {
+			return fmt.Errorf("value is greater than %v", def.MaxValue)
+		}
+
+		if def.Type == discordgo.ApplicationCommandOptionInteger {
+			field.SetInt(int64(value))
+		} else {
+			field.SetFloat(value)
+		}
+	case discordgo.ApplicationCommandOptionBoolean:
+		field.SetBool(option.BoolValue())
+	case discordgo.ApplicationCommandOptionUser, discordgo.ApplicationCommandOptionChannel, discordgo.ApplicationCommandOptionRole:
+		value, ok := resolvedValue(def.Type, option, resolved)
+		if !ok {
+			return fmt.Errorf("value is not resolved")
+		}
+
+		field.Set(reflect.ValueOf(value))
+	}
+
+	return nil
+}
+
+// Getting a resolved discord user, channel or role by the option value id.
+func resolvedValue(
+	t discordgo.ApplicationCommandOptionType,
+	option *discordgo.ApplicationCommandInteractionDataOption,
+	resolved *discordgo.ApplicationCommandInteractionDataResolved,
+) (any, bool) {
+	if resolved == nil {
+		return nil, false
+	}
+
+	id, _ := option.Value.(string)
+
+	switch t {
+	case discordgo.ApplicationCommandOptionUser:
+		user, ok := resolved.Users[id]
+		return user, ok
+	case discordgo.ApplicationCommandOptionChannel:
+		channel, ok := resolved.Channels[id]
+		return channel, ok
+	case discordgo.ApplicationCommandOptionRole:
+		role, ok := resolved.Roles[id]
+		return role, ok
+	default:
+		return nil, false
+	}
+}
+
+// Getting discord application command option fields from the struct type.
+func optionFields(t reflect.Type) ([]optionField, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be a struct, got %v", t)
+	}
+
+	fields := make([]optionField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, ok := field.Tag.Lookup(optionTag)
+		if !ok {
+			continue
+		}
+
+		optionType, ok := optionTypes[field.Type]
+		if !ok {
+			return nil, fmt.Errorf("field %s has unsupported option type %s", field.Name, field.Type)
+		}
+
+		option, err := parseOptionTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		option.Type = optionType
+		option.Description = field.Tag.Get(descriptionTag)
+
+		fields = append(fields, optionField{index: i, option: option})
+	}
+
+	return fields, nil
+}
+
+// Parsing discord application command option tag.
+func parseOptionTag(tag string) (discordgo.ApplicationCommandOption, error) {
+	parts := strings.Split(tag, ",")
+
+	option := discordgo.ApplicationCommandOption{Name: parts[0]}
+	if option.Name == "" {
+		return option, fmt.Errorf("option name is empty")
+	}
+
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(part, "=")
+
+		switch key {
+		case "required":
+			option.Required = true
+		case "autocomplete":
+			option.Autocomplete = true
+		case "min", "max":
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return option, fmt.Errorf("invalid %s value: %w", key, err)
+			}
+
+			if key == "min" {
+				option.MinValue = &number
+			} else {
+				option.MaxValue = number
+			}
+		case "minlen", "maxlen":
+			length, err := strconv.Atoi(value)
+			if err != nil {
+				return option, fmt.Errorf("invalid %s value: %w", key, err)
+			}
+
+			if key == "minlen" {
+				option.MinLength = &length
+			} else {
+				option.MaxLength = length
+			}
+		default:
+			return option, fmt.Errorf("unknown option flag %s", key)
+		}
+	}
+
+	return option, nil
+}
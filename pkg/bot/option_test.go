@@ -0,0 +1,198 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Testing command options.
+type testOptions struct {
+	User   *discordgo.User `option:"user,required" description:"User."`
+	Amount int             `option:"amount,required,min=-100,max=100" description:"Amount."`
+	Reason string          `option:"reason,maxlen=10" description:"Reason."`
+	Skip   string
+}
+
+// Test getting options from struct tags.
+func TestOptions(t *testing.T) {
+	min := float64(-100)
+	maxLength := 10
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		v       any
+		want    []*discordgo.ApplicationCommandOption
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			v:    testOptions{},
+			want: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "User.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "amount",
+					Description: "Amount.",
+					Required:    true,
+					MinValue:    &min,
+					MaxValue:    100,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reason",
+					Description: "Reason.",
+					MaxLength:   maxLength,
+				},
+			},
+		},
+		{
+			name: "Unsupported Type",
+			v: struct {
+				Values []string `option:"values"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Flag",
+			v: struct {
+				Value string `option:"value,optional"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "Required After Optional",
+			v: struct {
+				Reason string `option:"reason"`
+				Amount int    `option:"amount,required"`
+			}{},
+			wantErr: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Getting options from struct tags.
+			got, err := Options(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error getting options: %v", err)
+			}
+
+			// Check for similarity of a options.
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error options are not similar")
+			}
+		})
+	}
+}
+
+// Test binding interaction options.
+func TestBindOptions(t *testing.T) {
+	user := &discordgo.User{ID: "1000363996685271130", Username: "durudex"}
+
+	// Creating a new testing interaction.
+	interaction := func(options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name:     "update-balance",
+				Options:  options,
+				Resolved: &discordgo.ApplicationCommandInteractionDataResolved{Users: map[string]*discordgo.User{user.ID: user}},
+			},
+		}}
+	}
+
+	// Tests structures.
+	tests := []struct {
+		name    string
+		i       *discordgo.InteractionCreate
+		want    testOptions
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			i: interaction(
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionString, Name: "reason", Value: "Event"},
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Value: user.ID},
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionInteger, Name: "amount", Value: float64(50)},
+			),
+			want: testOptions{User: user, Amount: 50, Reason: "Event"},
+		},
+		{
+			name: "Optional",
+			i: interaction(
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Value: user.ID},
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionInteger, Name: "amount", Value: float64(-5)},
+			),
+			want: testOptions{User: user, Amount: -5},
+		},
+		{
+			name: "Required",
+			i: interaction(
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Value: user.ID},
+			),
+			want:    testOptions{User: user},
+			wantErr: true,
+		},
+		{
+			name: "Characters Length",
+			i: interaction(
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionString, Name: "reason", Value: "Переможець"},
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Value: user.ID},
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionInteger, Name: "amount", Value: float64(50)},
+			),
+			want: testOptions{User: user, Amount: 50, Reason: "Переможець"},
+		},
+		{
+			name: "Out Of Range",
+			i: interaction(
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionUser, Name: "user", Value: user.ID},
+				&discordgo.ApplicationCommandInteractionDataOption{Type: discordgo.ApplicationCommandOptionInteger, Name: "amount", Value: float64(500)},
+			),
+			want:    testOptions{User: user},
+			wantErr: true,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testOptions
+
+			// Binding interaction options.
+			if err := BindOptions(tt.i, &got); (err != nil) != tt.wantErr {
+				t.Errorf("error binding options: %v", err)
+			}
+
+			// Check for similarity of a options.
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error options are not similar: %+v", got)
+			}
+		})
+	}
+}
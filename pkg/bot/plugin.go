@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Discord bot plugin interface.
+type Plugin interface {
+	// Getting the plugin name.
+	Name() string
+	// Getting the plugin application commands.
+	Commands() []*Command
+	// Getting the plugin message components.
+	Components() []*Component
+	// Getting the plugin modals.
+	Modals() []*Modal
+	// Getting the plugin discord event handlers.
+	Handlers() []any
+	// Starting the plugin, it is called after the plugin is registered.
+	Start(ctx context.Context) error
+	// Stopping the plugin, it is called when the bot is closed.
+	Stop(ctx context.Context) error
+}
+
+// Registering a discord bot plugin. Application commands with the disabled
+// names are not registered.
+func (b *Bot) RegisterPlugin(p Plugin, disabled ...string) error {
+	// Checking is plugin already registered.
+	for _, plugin := range b.plugins {
+		if plugin.Name() == p.Name() {
+			return fmt.Errorf("plugin %s already registered", p.Name())
+		}
+	}
+
+	commands := p.Commands()
+
+	// Checking is disabled commands exists in the plugin.
+	for _, name := range disabled {
+		if !hasCommand(commands, name) {
+			return fmt.Errorf("plugin %s has no command %s", p.Name(), name)
+		}
+	}
+
+	for _, c := range commands {
+		// Checking is command disabled.
+		if contains(disabled, c.Name) {
+			continue
+		}
+
+		if err := b.RegisterCommand(c); err != nil {
+			return fmt.Errorf("plugin %s: %w", p.Name(), err)
+		}
+	}
+
+	for _, c := range p.Components() {
+		b.RegisterComponent(c)
+	}
+
+	for _, m := range p.Modals() {
+		b.RegisterModal(m)
+	}
+
+	for _, handler := range p.Handlers() {
+		b.RegisterHandler(handler)
+	}
+
+	// Starting the plugin.
+	if err := p.Start(b.ctx); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.Name(), err)
+	}
+
+	b.plugins = append(b.plugins, p)
+
+	log.Info().Str("plugin", p.Name()).Strs("disabled", disabled).Msg("plugin registered")
+
+	return nil
+}
+
+// Stopping all discord bot plugins in the reverse order of registration.
+func (b *Bot) stopPlugins(ctx context.Context) error {
+	for i := len(b.plugins) - 1; i >= 0; i-- {
+		if err := b.plugins[i].Stop(ctx); err != nil {
+			return fmt.Errorf("plugin %s: %w", b.plugins[i].Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Checking is the command with the name in the list of commands.
+func hasCommand(commands []*Command, name string) bool {
+	for _, c := range commands {
+		if c.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Checking is target in the list of values.
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+
+	return false
+}
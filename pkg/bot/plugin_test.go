@@ -0,0 +1,109 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Testing discord bot plugin.
+type testPlugin struct{ started bool }
+
+// Getting the testing plugin name.
+func (p *testPlugin) Name() string { return "test" }
+
+// Getting all testing plugin commands.
+func (p *testPlugin) Commands() []*Command {
+	handler := func(ctx context.Context, s Session, i *discordgo.InteractionCreate) {}
+
+	return []*Command{
+		{ApplicationCommand: discordgo.ApplicationCommand{Name: "first", Description: "First."}, Handler: handler},
+		{ApplicationCommand: discordgo.ApplicationCommand{Name: "second", Description: "Second."}, Handler: handler},
+	}
+}
+
+// Getting all testing plugin message components.
+func (p *testPlugin) Components() []*Component { return nil }
+
+// Getting all testing plugin modals.
+func (p *testPlugin) Modals() []*Modal { return nil }
+
+// Getting all testing plugin event handlers.
+func (p *testPlugin) Handlers() []any { return nil }
+
+// Starting the testing plugin.
+func (p *testPlugin) Start(ctx context.Context) error {
+	p.started = true
+	return nil
+}
+
+// Stopping the testing plugin.
+func (p *testPlugin) Stop(ctx context.Context) error { return nil }
+
+// Test registering a discord bot plugin.
+func TestBot_RegisterPlugin(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name         string
+		disabled     []string
+		wantCommands []string
+		wantErr      bool
+	}{
+		{name: "OK", wantCommands: []string{"first", "second"}},
+		{name: "Disabled", disabled: []string{"second"}, wantCommands: []string{"first"}},
+		{name: "Unknown Command", disabled: []string{"third"}, wantErr: true},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := New(&BotConfig{Token: "123"})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			p := &testPlugin{}
+
+			// Registering the plugin.
+			err = b.RegisterPlugin(p, tt.disabled...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error registering plugin: %v", err)
+			}
+
+			if len(b.commands) != len(tt.wantCommands) {
+				t.Errorf("error unexpected commands: %v", b.commands)
+			}
+			for _, name := range tt.wantCommands {
+				if _, ok := b.commands[handlerKey(discordgo.ChatApplicationCommand, name)]; !ok {
+					t.Errorf("error command %s is not registered", name)
+				}
+			}
+			if p.started == tt.wantErr {
+				t.Errorf("error unexpected plugin started status: %t", p.started)
+			}
+
+			// Checking is plugin registered twice.
+			if !tt.wantErr && b.RegisterPlugin(p) == nil {
+				t.Errorf("error plugin registered twice")
+			}
+		})
+	}
+}
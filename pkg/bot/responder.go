@@ -0,0 +1,229 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rs/zerolog/log"
+)
+
+// Default threshold after which the interaction response is deferred. Discord
+// waits for the initial response for three seconds.
+const DefaultDeferThreshold time.Duration = time.Second * 2
+
+// Discord interaction responder structure. If the response is not sent within
+// the threshold, the responder defers it and the original response is edited
+// when the response is sent.
+type Responder struct {
+	// Discord bot session.
+	session Session
+	// Discord interaction.
+	interaction *discordgo.Interaction
+	// Responder state mutex.
+	mutex sync.Mutex
+	// Defer response timer.
+	timer *time.Timer
+	// Interaction response deferred status.
+	deferred bool
+	// Interaction responded status.
+	responded bool
+}
+
+// Getting the discord interaction responder with the bot defer threshold, it
+// is created on the first call in the interaction handler and is stopped when
+// the interaction handling is finished.
+func (b *Bot) NewResponder(s Session, i *discordgo.InteractionCreate) *Responder {
+	// Checking is responder already created.
+	if r, ok := b.responders.Load(i.ID); ok {
+		return r.(*Responder)
+	}
+
+	r := NewResponder(s, i, b.deferThreshold)
+	b.responders.Store(i.ID, r)
+
+	return r
+}
+
+// Creating a new discord interaction responder. The response is deferred
+// automatically if it is not sent within the threshold.
+func NewResponder(s Session, i *discordgo.InteractionCreate, threshold time.Duration) *Responder {
+	r := &Responder{session: s, interaction: i.Interaction}
+
+	// Locking the responder until the timer is set, the timer can fire
+	// before it is assigned.
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.timer = time.AfterFunc(threshold, func() {
+		// Deferring interaction response.
+		if err := r.Defer(); err != nil {
+			log.Warn().Err(err).Msg("failed to defer interaction response")
+		}
+	})
+
+	return r
+}
+
+// Stopping the responder, the response is no longer deferred automatically.
+func (r *Responder) Stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.timer.Stop()
+}
+
+// Deferring the interaction response, discord shows that the bot is thinking
+// until the response is sent.
+func (r *Responder) Defer() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.timer.Stop()
+
+	// Checking is interaction already responded or deferred.
+	if r.responded || r.deferred {
+		return nil
+	}
+
+	// Send a interaction deferred respond.
+	// The response can be already deferred by the interactions endpoint.
+	if err := r.session.InteractionRespond(r.interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil && !errors.Is(err, errInteractionDeferred) {
+		return err
+	}
+
+	r.deferred = true
+
+	return nil
+}
+
+// Sending the interaction response. If the response has been deferred or
+// already sent, the original response is edited. The deferred response cannot
+// become ephemeral, so an ephemeral response replaces it with a follow-up
+// message.
+func (r *Responder) Respond(data *discordgo.InteractionResponseData) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.timer.Stop()
+
+	return r.respond(data)
+}
+
+// Sending the interaction response, the responder must be locked.
+func (r *Responder) respond(data *discordgo.InteractionResponseData) error {
+	switch {
+	case r.deferred && !r.responded && data.Flags&discordgo.MessageFlagsEphemeral != 0:
+		// Deleting the deferred interaction response.
+		if err := r.session.InteractionResponseDelete(r.interaction); err != nil {
+			return err
+		}
+
+		// Send a interaction ephemeral follow-up message.
+		if _, err := r.session.FollowupMessageCreate(r.interaction, true, &discordgo.WebhookParams{
+			Content:    data.Content,
+			Embeds:     data.Embeds,
+			Components: data.Components,
+			Flags:      data.Flags,
+		}); err != nil {
+			return err
+		}
+	case r.deferred || r.responded:
+		// Editing the original interaction response.
+		if _, err := r.session.InteractionResponseEdit(r.interaction, &discordgo.WebhookEdit{
+			Content:    &data.Content,
+			Embeds:     &data.Embeds,
+			Components: &data.Components,
+		}); err != nil {
+			return err
+		}
+	default:
+		// Send a interaction respond message.
+		if err := r.session.InteractionRespond(r.interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: data,
+		}); errors.Is(err, errInteractionDeferred) {
+			// The response has been deferred by the interactions endpoint,
+			// so the deferred response is edited.
+			r.deferred = true
+
+			return r.respond(data)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	r.responded = true
+
+	return nil
+}
+
+// Opening a modal as the interaction response, the response is no longer
+// deferred automatically.
+func (r *Responder) openModal(resp *discordgo.InteractionResponse) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.timer.Stop()
+
+	// Checking is interaction already responded or deferred.
+	if r.responded || r.deferred {
+		return errors.New("interaction already responded")
+	}
+
+	// Send a interaction modal respond.
+	if err := r.session.InteractionRespond(r.interaction, resp); err != nil {
+		r.deferred = errors.Is(err, errInteractionDeferred)
+
+		return err
+	}
+
+	r.responded = true
+
+	return nil
+}
+
+// Sending a follow-up message to the interaction. If the interaction has not
+// been responded, the response is deferred first.
+func (r *Responder) Followup(data *discordgo.WebhookParams) (*discordgo.Message, error) {
+	if err := r.Defer(); err != nil {
+		return nil, err
+	}
+
+	return r.session.FollowupMessageCreate(r.interaction, true, data)
+}
+
+// Getting the locale of the user who created the interaction.
+func (r *Responder) Locale() discordgo.Locale {
+	return r.interaction.Locale
+}
+
+// Getting the discord interaction.
+func (r *Responder) Interaction() *discordgo.Interaction {
+	return r.interaction
+}
+
+// Getting the discord session used by the responder.
+func (r *Responder) Session() Session {
+	return r.session
+}
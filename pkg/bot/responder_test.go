@@ -0,0 +1,217 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/durudex/discord-promo-bot/pkg/bot"
+	"github.com/durudex/discord-promo-bot/pkg/bot/bottest"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Test sending the interaction response.
+func TestResponder_Respond(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		deferred      bool
+		flags         discordgo.MessageFlags
+		wantResponses int
+		wantContent   string
+		wantFollowups int
+	}{
+		{name: "OK", wantResponses: 1, wantContent: "OK"},
+		{name: "Deferred", deferred: true, wantResponses: 1, wantContent: "OK"},
+		{
+			name:          "Deferred Ephemeral",
+			deferred:      true,
+			flags:         discordgo.MessageFlagsEphemeral,
+			wantResponses: 1,
+			wantFollowups: 1,
+		},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewSession()
+
+			// Creating a new interaction responder.
+			r := bot.NewResponder(s, bottest.Command("test"), time.Minute)
+
+			if tt.deferred {
+				// Deferring the interaction response.
+				if err := r.Defer(); err != nil {
+					t.Fatalf("error deferring response: %s", err.Error())
+				}
+			}
+
+			// Sending the interaction response.
+			if err := r.Respond(&discordgo.InteractionResponseData{Content: "OK", Flags: tt.flags}); err != nil {
+				t.Fatalf("error sending response: %s", err.Error())
+			}
+
+			if responses := s.Responses(); len(responses) != tt.wantResponses {
+				t.Errorf("error unexpected responses: %d", len(responses))
+			}
+			if content := s.Message().Content; content != tt.wantContent {
+				t.Errorf("error content are not similar: %s", content)
+			}
+			if followups := s.Followups(); len(followups) != tt.wantFollowups {
+				t.Errorf("error unexpected follow-up messages: %d", len(followups))
+			}
+		})
+	}
+}
+
+// Test deferring the interaction response automatically.
+func TestResponder_AutoDefer(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		action        func(r *bot.Responder) error
+		wantResponses []discordgo.InteractionResponseType
+		wantContent   string
+		stop          bool
+		wantFollowups int
+	}{
+		{
+			name:          "Deferred",
+			wantResponses: []discordgo.InteractionResponseType{discordgo.InteractionResponseDeferredChannelMessageWithSource},
+		},
+		{
+			name: "Deferred Edit",
+			action: func(r *bot.Responder) error {
+				return r.Respond(&discordgo.InteractionResponseData{Content: "OK"})
+			},
+			wantResponses: []discordgo.InteractionResponseType{discordgo.InteractionResponseDeferredChannelMessageWithSource},
+			wantContent:   "OK",
+		},
+		{
+			name: "Follow-up",
+			action: func(r *bot.Responder) error {
+				_, err := r.Followup(&discordgo.WebhookParams{Content: "OK"})
+				return err
+			},
+			wantResponses: []discordgo.InteractionResponseType{discordgo.InteractionResponseDeferredChannelMessageWithSource},
+			wantFollowups: 1,
+		},
+		{name: "Stopped", stop: true},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bottest.NewSession()
+
+			// Creating a new interaction responder.
+			r := bot.NewResponder(s, bottest.Command("test"), time.Millisecond*10)
+
+			// Checking is the responder stopped before the threshold.
+			if tt.stop {
+				r.Stop()
+			}
+
+			// Waiting for the defer threshold.
+			time.Sleep(time.Millisecond * 50)
+
+			if tt.action != nil {
+				if err := tt.action(r); err != nil {
+					t.Fatalf("error responding: %s", err.Error())
+				}
+			}
+
+			responses := s.Responses()
+			types := make([]discordgo.InteractionResponseType, 0, len(responses))
+
+			for _, response := range responses {
+				types = append(types, response.Type)
+			}
+
+			if len(types) != len(tt.wantResponses) || (len(types) != 0 && !reflect.DeepEqual(types, tt.wantResponses)) {
+				t.Errorf("error unexpected responses: %v", types)
+			}
+			if content := s.Message().Content; content != tt.wantContent {
+				t.Errorf("error content are not similar: %s", content)
+			}
+			if followups := s.Followups(); len(followups) != tt.wantFollowups {
+				t.Errorf("error unexpected follow-up messages: %d", len(followups))
+			}
+		})
+	}
+}
+
+// Test stopping the interaction responder when the interaction handler opens
+// a modal or returns without responding.
+func TestBot_NewResponder(t *testing.T) {
+	// Tests structures.
+	tests := []struct {
+		name          string
+		modal         bool
+		wantResponses int
+	}{
+		{name: "Modal", modal: true, wantResponses: 1},
+		{name: "No Response"},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bot.New(&bot.BotConfig{Token: "123", DeferThreshold: time.Millisecond * 10})
+			if err != nil {
+				t.Fatalf("error creating bot: %s", err.Error())
+			}
+
+			b.RegisterModal(&bot.Modal{ModalID: "modal", Title: "Modal."})
+
+			// Registering a testing application command.
+			if err := b.RegisterCommand(&bot.Command{
+				ApplicationCommand: discordgo.ApplicationCommand{Name: "test", Description: "Test."},
+				Handler: func(ctx context.Context, s bot.Session, i *discordgo.InteractionCreate) {
+					b.NewResponder(s, i)
+
+					// Checking is modal opened.
+					if tt.modal {
+						if err := b.OpenModal(s, i, "modal"); err != nil {
+							t.Errorf("error opening modal: %s", err.Error())
+						}
+					}
+				},
+			}); err != nil {
+				t.Fatalf("error registering command: %s", err.Error())
+			}
+
+			s := bottest.NewSession()
+
+			// Handling the interaction.
+			b.Handle(s, bottest.Command("test"))
+
+			// Waiting for the defer threshold.
+			time.Sleep(time.Millisecond * 50)
+
+			if responses := s.Responses(); len(responses) != tt.wantResponses {
+				t.Errorf("error unexpected responses: %v", responses)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import "strings"
+
+// Custom id parts separator.
+const CustomIDSeparator string = ":"
+
+// Custom id parameters parsed from the custom id by pattern.
+type Params map[string]string
+
+// Getting a custom id parameter.
+func (p Params) Get(name string) string {
+	return p[name]
+}
+
+// Creating a new custom id from parts, for example "review:approve:<userID>".
+func CustomID(parts ...string) string {
+	return strings.Join(parts, CustomIDSeparator)
+}
+
+// Custom id route structure.
+type route[T any] struct {
+	// Custom id pattern parts, parameters are specified as "{name}".
+	parts []string
+	// Route value.
+	value T
+}
+
+// Custom id router structure. Patterns without parameters are matched exactly,
+// the rest are matched in the order of registration.
+type router[T any] struct {
+	// Routes without parameters.
+	exact map[string]T
+	// Routes with parameters.
+	patterns []route[T]
+}
+
+// Creating a new custom id router.
+func newRouter[T any]() *router[T] {
+	return &router[T]{exact: make(map[string]T)}
+}
+
+// Adding a new custom id pattern route, for example "review:approve:{user}".
+func (r *router[T]) Add(pattern string, value T) {
+	parts := strings.Split(pattern, CustomIDSeparator)
+
+	for _, part := range parts {
+		// Checking is part of the pattern a parameter.
+		if isParam(part) {
+			r.patterns = append(r.patterns, route[T]{parts: parts, value: value})
+			return
+		}
+	}
+
+	r.exact[pattern] = value
+}
+
+// Matching a custom id with the registered routes.
+func (r *router[T]) Match(id string) (T, Params, bool) {
+	// Checking is custom id matches exactly.
+	if value, ok := r.exact[id]; ok {
+		return value, Params{}, true
+	}
+
+	parts := strings.Split(id, CustomIDSeparator)
+
+	for _, route := range r.patterns {
+		if params, ok := route.match(parts); ok {
+			return route.value, params, true
+		}
+	}
+
+	var zero T
+
+	return zero, nil, false
+}
+
+// Matching a custom id parts with the route pattern.
+func (r route[T]) match(parts []string) (Params, bool) {
+	if len(parts) != len(r.parts) {
+		return nil, false
+	}
+
+	params := make(Params)
+
+	for i, part := range r.parts {
+		if isParam(part) {
+			params[part[1:len(part)-1]] = parts[i]
+		} else if part != parts[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// Checking is custom id pattern part a parameter.
+func isParam(part string) bool {
+	return len(part) > 2 && strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}")
+}
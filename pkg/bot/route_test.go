@@ -0,0 +1,75 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test matching custom id with the router.
+func TestRouter_Match(t *testing.T) {
+	r := newRouter[string]()
+
+	r.Add("use", "use")
+	r.Add("review:approve:{user}", "approve")
+	r.Add("page:{name}:{page}", "page")
+
+	// Tests structures.
+	tests := []struct {
+		name       string
+		id         string
+		want       string
+		wantParams Params
+		wantOk     bool
+	}{
+		{name: "Exact", id: "use", want: "use", wantParams: Params{}, wantOk: true},
+		{
+			name:       "Pattern",
+			id:         CustomID("review", "approve", "1000363996685271130"),
+			want:       "approve",
+			wantParams: Params{"user": "1000363996685271130"},
+			wantOk:     true,
+		},
+		{
+			name:       "Many Params",
+			id:         "page:users:2",
+			want:       "page",
+			wantParams: Params{"name": "users", "page": "2"},
+			wantOk:     true,
+		},
+		{name: "Static Mismatch", id: "review:reject:1"},
+		{name: "Length Mismatch", id: "review:approve"},
+	}
+
+	// Conducting tests in various structures.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Matching custom id.
+			got, params, ok := r.Match(tt.id)
+			if ok != tt.wantOk {
+				t.Errorf("error matching custom id: %v", ok)
+			}
+
+			// Check for similarity of a route.
+			if got != tt.want || !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("error route are not similar: %s %v", got, params)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright © 2022 Durudex
+ *
+ * This file is part of Durudex: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * Durudex is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with Durudex. If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bot
+
+import "github.com/bwmarrin/discordgo"
+
+// Discord session interface, it contains the session operations used by the
+// interaction handlers. It is implemented by *discordgo.Session.
+type Session interface {
+	// Responding to the discord interaction.
+	InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse) error
+	// Deleting the original discord interaction response.
+	InteractionResponseDelete(interaction *discordgo.Interaction) error
+	// Editing the original discord interaction response.
+	InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit) (*discordgo.Message, error)
+	// Creating a new discord interaction follow-up message.
+	FollowupMessageCreate(
+		interaction *discordgo.Interaction,
+		wait bool,
+		data *discordgo.WebhookParams,
+	) (*discordgo.Message, error)
+	// Sending a discord embed message to the channel.
+	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed) (*discordgo.Message, error)
+}
+
+// Checking is discord session implements the session interface.
+var _ Session = (*discordgo.Session)(nil)